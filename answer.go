@@ -0,0 +1,256 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var errAnswerNoOffer = errors.New("sdp: no offer to answer")
+
+// MediaCapabilities describes what the answerer supports for one media type.
+type MediaCapabilities struct {
+	// Codecs lists the supported codecs. A codec matches an offered codec
+	// when every non-empty field except PayloadType and RTCPFeedback is equal,
+	// as in GetPayloadTypeForCodec. The offered payload type and fmtp are kept
+	// in the answer, and RTCPFeedback is intersected with the offered feedback.
+	Codecs []Codec
+
+	// HeaderExtensions lists the URIs of the supported RTP header extensions.
+	// Offered extensions are kept with their offered ID, as in
+	// NegotiateExtMaps.
+	HeaderExtensions []string
+
+	// Direction is the direction the answerer wants for this media type.
+	// DirectionSendRecv is used when unset.
+	Direction Direction
+
+	// Transports lists the supported transport protocols such as
+	// "UDP/TLS/RTP/SAVPF". Any offered transport is accepted when empty.
+	Transports []string
+}
+
+// AnswerOptions carries the local capabilities used by NewAnswer.
+type AnswerOptions struct {
+	// Media holds the capabilities per media type ("audio", "video",
+	// "application", ...). Offered m-sections with a media type that is not
+	// present are rejected.
+	Media map[string]MediaCapabilities
+}
+
+// NewAnswer creates an answer to the offer according to the offer/answer
+// model. Each offered m-section is answered in the same order:
+//
+//   - an m-section is rejected with port 0 when the offer already rejected it,
+//     when there are no capabilities for its media type or transport, or when
+//     no offered codec is supported
+//   - codecs are the intersection of the offered and supported codecs, in
+//     offer order, keeping the offered payload types
+//   - the direction is the reverse of the offered direction restricted to the
+//     local direction
+//   - the offered a=mid is echoed, and BUNDLE groups list the accepted mids
+//
+// Transport parameters such as ICE credentials, fingerprints and candidates
+// are not added and have to be set on the returned description by the caller.
+//
+// https://datatracker.ietf.org/doc/html/rfc3264#section-6
+func NewAnswer(offer *SessionDescription, options AnswerOptions) (*SessionDescription, error) {
+	if offer == nil {
+		return nil, errAnswerNoOffer
+	}
+
+	answer, err := NewJSEPSessionDescription(false)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := offer.Attribute(AttrKeyExtMapAllowMixed); ok {
+		answer.WithPropertyAttribute(AttrKeyExtMapAllowMixed)
+	}
+	for _, offered := range offer.MediaDescriptions {
+		answer.WithMedia(answerMediaDescription(offer, offered, options))
+	}
+
+	groups, err := offer.GroupsBySemantics(SemanticTokenBundle)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		answered := Group{Semantics: group.Semantics}
+		for _, mid := range group.MIDs {
+			if media, ok := answer.MediaDescriptionByMID(mid); ok && !media.IsRejected() {
+				answered.MIDs = append(answered.MIDs, mid)
+			}
+		}
+		if len(answered.MIDs) > 0 {
+			answer.WithGroup(answered)
+		}
+	}
+
+	return answer, nil
+}
+
+func answerMediaDescription(
+	offer *SessionDescription,
+	offered *MediaDescription,
+	options AnswerOptions,
+) *MediaDescription {
+	capabilities, ok := options.Media[offered.MediaName.Media]
+	if !ok || offered.IsRejected() || !capabilities.supportsTransport(offered.MediaName.Protos) {
+		return rejectedMediaDescription(offered)
+	}
+
+	answered := NewJSEPMediaDescription(offered.MediaName.Media, nil)
+	answered.MediaName.Protos = slices.Clone(offered.MediaName.Protos)
+	if mid, ok := offered.Attribute(AttrKeyMID); ok {
+		answered.WithValueAttribute(AttrKeyMID, mid)
+	}
+
+	if !isRTPProtocol(offered.MediaName.Protos) {
+		// Non-RTP formats such as webrtc-datachannel are accepted as offered.
+		answered.MediaName.Formats = slices.Clone(offered.MediaName.Formats)
+		for _, key := range []string{"sctp-port", "max-message-size"} {
+			if value, ok := offered.Attribute(key); ok {
+				answered.WithValueAttribute(key, value)
+			}
+		}
+
+		return answered
+	}
+
+	codecs := intersectCodecs(offered.Codecs(), capabilities.Codecs)
+	if len(codecs) == 0 {
+		return rejectedMediaDescription(offered)
+	}
+
+	if _, ok := offered.Attribute(AttrKeyExtMapAllowMixed); ok {
+		answered.WithPropertyAttribute(AttrKeyExtMapAllowMixed)
+	}
+	offeredExtMaps, _ := offered.ExtMaps()
+	allowMixed := offered.AllowsMixedExtMaps(offer)
+	for _, extMap := range NegotiateExtMaps(offeredExtMaps, capabilities.HeaderExtensions, allowMixed) {
+		answered.Attributes = append(answered.Attributes, extMap.Clone())
+	}
+
+	answered.WithPropertyAttribute(
+		NegotiateDirection(capabilities.Direction, offered.EffectiveDirection(offer)).String(),
+	)
+
+	if _, ok := offered.Attribute(AttrKeyRTCPMux); ok {
+		answered.WithPropertyAttribute(AttrKeyRTCPMux)
+	}
+	if _, ok := offered.Attribute(AttrKeyRTCPRsize); ok {
+		answered.WithPropertyAttribute(AttrKeyRTCPRsize)
+	}
+
+	for _, codec := range codecs {
+		channels, _ := strconv.ParseUint(codec.EncodingParameters, 10, 16)
+		answered.WithCodec(codec.PayloadType, codec.Name, codec.ClockRate, uint16(channels), codec.Fmtp)
+		for _, feedback := range codec.RTCPFeedback {
+			answered.WithValueAttribute("rtcp-fb", fmt.Sprintf("%d %s", codec.PayloadType, feedback))
+		}
+	}
+
+	return answered
+}
+
+// rejectedMediaDescription answers an m-section with port 0. The offered
+// formats are kept since the m= line requires at least one.
+// https://datatracker.ietf.org/doc/html/rfc3264#section-6
+func rejectedMediaDescription(offered *MediaDescription) *MediaDescription {
+	rejected := NewJSEPMediaDescription(offered.MediaName.Media, nil)
+	rejected.MediaName.Port = RangedPort{Value: 0}
+	rejected.MediaName.Protos = slices.Clone(offered.MediaName.Protos)
+	rejected.MediaName.Formats = slices.Clone(offered.MediaName.Formats)
+	if mid, ok := offered.Attribute(AttrKeyMID); ok {
+		rejected.WithValueAttribute(AttrKeyMID, mid)
+	}
+
+	return rejected
+}
+
+func (c MediaCapabilities) supportsTransport(protos []string) bool {
+	if len(c.Transports) == 0 {
+		return true
+	}
+
+	return slices.Contains(c.Transports, strings.Join(protos, "/"))
+}
+
+func isRTPProtocol(protos []string) bool {
+	return slices.Contains(protos, "RTP")
+}
+
+// intersectCodecs returns the offered codecs that are supported, in offer
+// order. Retransmission codecs are only kept when the codec they repair is.
+func intersectCodecs(offered, supported []Codec) []Codec {
+	var accepted []Codec
+	for _, codec := range offered {
+		for _, local := range supported {
+			if !codecsMatch(local, codec) {
+				continue
+			}
+			codec.RTCPFeedback = intersectRTCPFeedback(codec.RTCPFeedback, local.RTCPFeedback)
+			accepted = append(accepted, codec)
+
+			break
+		}
+	}
+
+	// The payload types are collected before filtering in place.
+	payloadTypes := map[uint8]bool{}
+	for _, codec := range accepted {
+		payloadTypes[codec.PayloadType] = true
+	}
+
+	result := accepted[:0]
+	for _, codec := range accepted {
+		if apt, ok := associatedPayloadType(codec); ok && !payloadTypes[apt] {
+			continue
+		}
+		result = append(result, codec)
+	}
+
+	return result
+}
+
+func intersectRTCPFeedback(offered, supported []string) []string {
+	var result []string
+	for _, feedback := range offered {
+		if slices.Contains(supported, feedback) {
+			result = append(result, feedback)
+		}
+	}
+
+	return result
+}
+
+// associatedPayloadType returns the apt= payload type of a retransmission
+// codec.
+// https://datatracker.ietf.org/doc/html/rfc4588#section-8.1
+func associatedPayloadType(codec Codec) (uint8, bool) {
+	if !strings.EqualFold(codec.Name, "rtx") {
+		return 0, false
+	}
+
+	for param := range strings.SplitSeq(codec.Fmtp, ";") {
+		key, value, found := strings.Cut(strings.TrimSpace(param), "=")
+		if !found || key != "apt" {
+			continue
+		}
+
+		apt, err := strconv.ParseUint(value, 10, 8)
+		if err != nil {
+			return 0, false
+		}
+
+		return uint8(apt), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,165 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const answerOfferSDP = "v=0\r\n" +
+	"o=- 4215775240449105457 2 IN IP4 127.0.0.1\r\n" +
+	"s=-\r\n" +
+	"t=0 0\r\n" +
+	"a=group:BUNDLE 0 1 2 3\r\n" +
+	"m=audio 9 UDP/TLS/RTP/SAVPF 111 0\r\n" +
+	"c=IN IP4 0.0.0.0\r\n" +
+	"a=mid:0\r\n" +
+	"a=extmap:1 urn:ietf:params:rtp-hdrext:ssrc-audio-level\r\n" +
+	"a=extmap:3 urn:ietf:params:rtp-hdrext:sdes:mid\r\n" +
+	"a=sendonly\r\n" +
+	"a=rtcp-mux\r\n" +
+	"a=rtpmap:111 opus/48000/2\r\n" +
+	"a=rtcp-fb:111 transport-cc\r\n" +
+	"a=fmtp:111 minptime=10;useinbandfec=1\r\n" +
+	"m=video 9 UDP/TLS/RTP/SAVPF 96 97 98\r\n" +
+	"c=IN IP4 0.0.0.0\r\n" +
+	"a=mid:1\r\n" +
+	"a=rtcp-mux\r\n" +
+	"a=rtpmap:96 VP8/90000\r\n" +
+	"a=rtcp-fb:96 nack\r\n" +
+	"a=rtcp-fb:96 goog-remb\r\n" +
+	"a=rtpmap:97 rtx/90000\r\n" +
+	"a=fmtp:97 apt=96\r\n" +
+	"a=rtpmap:98 rtx/90000\r\n" +
+	"a=fmtp:98 apt=100\r\n" +
+	"m=text 9 UDP/TLS/RTP/SAVPF 100\r\n" +
+	"c=IN IP4 0.0.0.0\r\n" +
+	"a=mid:2\r\n" +
+	"a=rtpmap:100 t140/1000\r\n" +
+	"m=application 9 UDP/DTLS/SCTP webrtc-datachannel\r\n" +
+	"c=IN IP4 0.0.0.0\r\n" +
+	"a=mid:3\r\n" +
+	"a=sctp-port:5000\r\n"
+
+func TestNewAnswer(t *testing.T) {
+	offer := &SessionDescription{}
+	assert.NoError(t, offer.UnmarshalString(answerOfferSDP))
+
+	answer, err := NewAnswer(offer, AnswerOptions{
+		Media: map[string]MediaCapabilities{
+			"audio": {
+				Codecs:           []Codec{{Name: "opus", ClockRate: 48000}},
+				HeaderExtensions: []string{SDESMidURI},
+			},
+			"video": {
+				Codecs: []Codec{
+					{Name: "vp8", RTCPFeedback: []string{"nack"}},
+					{Name: "rtx"},
+				},
+				Direction: DirectionRecvOnly,
+			},
+			"application": {Transports: []string{"UDP/DTLS/SCTP"}},
+		},
+	})
+	assert.NoError(t, err)
+
+	raw, err := answer.Marshal()
+	assert.NoError(t, err)
+
+	// Skip the session part which contains a random session ID.
+	_, media, found := strings.Cut(string(raw), "t=0 0\r\n")
+	assert.True(t, found)
+	assert.Equal(t, "a=group:BUNDLE 0 1 3\r\n"+
+		"m=audio 9 UDP/TLS/RTP/SAVPF 111\r\n"+
+		"c=IN IP4 0.0.0.0\r\n"+
+		"a=mid:0\r\n"+
+		"a=extmap:3 urn:ietf:params:rtp-hdrext:sdes:mid\r\n"+
+		"a=recvonly\r\n"+
+		"a=rtcp-mux\r\n"+
+		"a=rtpmap:111 opus/48000/2\r\n"+
+		"a=fmtp:111 minptime=10;useinbandfec=1\r\n"+
+		"m=video 9 UDP/TLS/RTP/SAVPF 96 97\r\n"+
+		"c=IN IP4 0.0.0.0\r\n"+
+		"a=mid:1\r\n"+
+		"a=recvonly\r\n"+
+		"a=rtcp-mux\r\n"+
+		"a=rtpmap:96 VP8/90000\r\n"+
+		"a=rtcp-fb:96 nack\r\n"+
+		"a=rtpmap:97 rtx/90000\r\n"+
+		"a=fmtp:97 apt=96\r\n"+
+		"m=text 0 UDP/TLS/RTP/SAVPF 100\r\n"+
+		"c=IN IP4 0.0.0.0\r\n"+
+		"a=mid:2\r\n"+
+		"m=application 9 UDP/DTLS/SCTP webrtc-datachannel\r\n"+
+		"c=IN IP4 0.0.0.0\r\n"+
+		"a=mid:3\r\n"+
+		"a=sctp-port:5000\r\n", media)
+}
+
+func TestNewAnswer_Rejects(t *testing.T) {
+	offer := &SessionDescription{}
+	assert.NoError(t, offer.UnmarshalString(answerOfferSDP))
+
+	for _, test := range []struct {
+		name    string
+		options AnswerOptions
+	}{
+		{"no capabilities", AnswerOptions{}},
+		{"unsupported transport", AnswerOptions{Media: map[string]MediaCapabilities{
+			"audio": {Codecs: []Codec{{Name: "opus"}}, Transports: []string{"RTP/AVP"}},
+		}}},
+		{"no common codec", AnswerOptions{Media: map[string]MediaCapabilities{
+			"audio": {Codecs: []Codec{{Name: "G722"}}},
+		}}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			answer, err := NewAnswer(offer, test.options)
+			assert.NoError(t, err)
+			assert.Len(t, answer.MediaDescriptions, len(offer.MediaDescriptions))
+
+			audio := answer.MediaDescriptions[0]
+			assert.Equal(t, 0, audio.MediaName.Port.Value)
+			assert.Equal(t, []string{"111", "0"}, audio.MediaName.Formats)
+			mid, _ := audio.Attribute(AttrKeyMID)
+			assert.Equal(t, "0", mid)
+		})
+	}
+
+	_, err := NewAnswer(nil, AnswerOptions{})
+	assert.ErrorIs(t, err, errAnswerNoOffer)
+}
+
+func TestIntersectCodecs_FmtpRules(t *testing.T) {
+	offered := []Codec{
+		{PayloadType: 102, Name: "H264", ClockRate: 90000, Fmtp: "profile-level-id=640c1f;packetization-mode=1"},
+		{PayloadType: 104, Name: "H264", ClockRate: 90000, Fmtp: "profile-level-id=42e034;packetization-mode=1"},
+	}
+	supported := []Codec{{Name: "H264", Fmtp: "profile-level-id=42e01f;packetization-mode=1"}}
+
+	accepted := intersectCodecs(offered, supported)
+	assert.Len(t, accepted, 1)
+	assert.Equal(t, uint8(104), accepted[0].PayloadType)
+}
+
+func TestIntersectCodecs_RTX(t *testing.T) {
+	offered := []Codec{
+		{PayloadType: 96, Name: "VP8", ClockRate: 90000},
+		{PayloadType: 97, Name: "rtx", ClockRate: 90000, Fmtp: "apt=96"},
+		{PayloadType: 102, Name: "H264", ClockRate: 90000, Fmtp: "profile-level-id=640c1f;packetization-mode=1"},
+		{PayloadType: 103, Name: "rtx", ClockRate: 90000, Fmtp: "apt=102"},
+		{PayloadType: 98, Name: "VP9", ClockRate: 90000},
+		{PayloadType: 99, Name: "rtx", ClockRate: 90000, Fmtp: "apt=98"},
+	}
+	supported := []Codec{{Name: "VP8"}, {Name: "VP9"}, {Name: "rtx"}}
+
+	// The retransmission codec of the unsupported H264 is dropped.
+	var payloadTypes []uint8
+	for _, codec := range intersectCodecs(offered, supported) {
+		payloadTypes = append(payloadTypes, codec.PayloadType)
+	}
+	assert.Equal(t, []uint8{96, 97, 98, 99}, payloadTypes)
+}
@@ -26,9 +26,21 @@ func (e syntaxError) Error() string {
 	return fmt.Sprintf("sdp: syntax error at pos %d: %s", e.i, strconv.QuoteToASCII(e.s[e.i:e.i+1]))
 }
 
+func (e syntaxError) Unwrap() error {
+	return ErrSDPInvalidSyntax
+}
+
 type baseLexer struct {
 	value string
 	pos   int
+
+	// lineStart and fieldStart are the offsets of the current line and of
+	// the last field read, used to locate parse errors.
+	lineStart  int
+	fieldStart int
+
+	// maxLineLength is Limits.MaxLineLength.
+	maxLineLength int
 }
 
 func (l baseLexer) syntaxError() error {
@@ -83,6 +95,7 @@ func (l *baseLexer) readWhitespace() error { //notlint:cyclop
 }
 
 func (l *baseLexer) readUint64Field() (i uint64, err error) { //nolint:cyclop
+	l.fieldStart = l.pos
 	for {
 		ch, err := l.readByte()
 		if errors.Is(err, io.EOF) && i > 0 {
@@ -139,6 +152,7 @@ func (l *baseLexer) readUint64Field() (i uint64, err error) { //nolint:cyclop
 // Returns next field on this line or empty string if no more fields on line.
 func (l *baseLexer) readField() (string, error) {
 	start := l.pos
+	l.fieldStart = start
 	var stop int
 	for {
 		stop = l.pos
@@ -182,9 +196,25 @@ func (l *lexer) readRequiredField() (string, error) {
 	return field, nil
 }
 
+// Skips the remainder of the current line including its line ending.
+func (l *baseLexer) skipLine() error {
+	for {
+		ch, err := l.readByte()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if ch == '\n' {
+			return nil
+		}
+	}
+}
+
 // Returns symbols until line end.
 func (l *baseLexer) readLine() (string, error) {
 	start := l.pos
+	l.fieldStart = start
 	trim := 1
 	for {
 		ch, err := l.readByte()
@@ -210,6 +240,11 @@ func (l *baseLexer) readType() (byte, error) {
 		if isNewline(firstByte) {
 			continue
 		}
+		l.lineStart = l.pos - 1
+		l.fieldStart = l.lineStart
+		if err := l.checkLineLength(); err != nil {
+			return firstByte, err
+		}
 
 		secondByte, err := l.readByte()
 		if err != nil {
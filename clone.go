@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import "slices"
+
+// Clone returns a deep copy of the session description, which can be
+// modified without affecting the original.
+func (s *SessionDescription) Clone() *SessionDescription {
+	if s == nil {
+		return nil
+	}
+
+	clone := *s
+	clone.SessionInformation = clonePointer(s.SessionInformation)
+	clone.EmailAddress = clonePointer(s.EmailAddress)
+	clone.PhoneNumber = clonePointer(s.PhoneNumber)
+	clone.ConnectionInformation = s.ConnectionInformation.Clone()
+	clone.Bandwidth = slices.Clone(s.Bandwidth)
+	clone.TimeZones = slices.Clone(s.TimeZones)
+	clone.EncryptionKey = clonePointer(s.EncryptionKey)
+	clone.Attributes = slices.Clone(s.Attributes)
+
+	// The user info of an URL is immutable and can be shared.
+	clone.URI = clonePointer(s.URI)
+
+	if s.TimeDescriptions != nil {
+		clone.TimeDescriptions = make([]TimeDescription, len(s.TimeDescriptions))
+		for i, td := range s.TimeDescriptions {
+			clone.TimeDescriptions[i] = td
+			if td.RepeatTimes != nil {
+				clone.TimeDescriptions[i].RepeatTimes = make([]RepeatTime, len(td.RepeatTimes))
+				for j, r := range td.RepeatTimes {
+					r.Offsets = slices.Clone(r.Offsets)
+					clone.TimeDescriptions[i].RepeatTimes[j] = r
+				}
+			}
+		}
+	}
+
+	if s.MediaDescriptions != nil {
+		clone.MediaDescriptions = make([]*MediaDescription, len(s.MediaDescriptions))
+		for i, md := range s.MediaDescriptions {
+			clone.MediaDescriptions[i] = md.Clone()
+		}
+	}
+
+	return &clone
+}
+
+// Clone returns a deep copy of the media description.
+func (d *MediaDescription) Clone() *MediaDescription {
+	if d == nil {
+		return nil
+	}
+
+	clone := *d
+	clone.MediaName.Port.Range = clonePointer(d.MediaName.Port.Range)
+	clone.MediaName.Protos = slices.Clone(d.MediaName.Protos)
+	clone.MediaName.Formats = slices.Clone(d.MediaName.Formats)
+	clone.MediaTitle = clonePointer(d.MediaTitle)
+	clone.ConnectionInformation = d.ConnectionInformation.Clone()
+	clone.Bandwidth = slices.Clone(d.Bandwidth)
+	clone.EncryptionKey = clonePointer(d.EncryptionKey)
+	clone.Attributes = slices.Clone(d.Attributes)
+
+	return &clone
+}
+
+// Clone returns a deep copy of the connection information.
+func (c *ConnectionInformation) Clone() *ConnectionInformation {
+	if c == nil {
+		return nil
+	}
+
+	clone := *c
+	clone.Address = c.Address.Clone()
+
+	return &clone
+}
+
+// Clone returns a deep copy of the address.
+func (c *Address) Clone() *Address {
+	if c == nil {
+		return nil
+	}
+
+	clone := *c
+	clone.TTL = clonePointer(c.TTL)
+	clone.Range = clonePointer(c.Range)
+
+	return &clone
+}
+
+func clonePointer[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+
+	return &v
+}
@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionDescription_Clone(t *testing.T) {
+	ttl, addressRange, portRange := 127, 3, 2
+	information := Information("info")
+	email := EmailAddress("a@example.com")
+	key := EncryptionKey("prompt")
+	uri, err := url.Parse("https://user@example.com/session")
+	assert.NoError(t, err)
+
+	s := &SessionDescription{
+		SessionInformation: &information,
+		URI:                uri,
+		EmailAddress:       &email,
+		ConnectionInformation: &ConnectionInformation{
+			NetworkType: "IN", AddressType: "IP4",
+			Address: &Address{Address: "224.2.1.1", TTL: &ttl, Range: &addressRange},
+		},
+		Bandwidth:        []Bandwidth{{Type: "AS", Bandwidth: 64}},
+		TimeDescriptions: []TimeDescription{{RepeatTimes: []RepeatTime{{Interval: 1, Offsets: []int64{0}}}}},
+		TimeZones:        []TimeZone{{AdjustmentTime: 1, Offset: 2}},
+		EncryptionKey:    &key,
+		Attributes:       []Attribute{{Key: "tool", Value: "a"}},
+		MediaDescriptions: []*MediaDescription{{
+			MediaName: MediaName{
+				Media: "audio", Port: RangedPort{Value: 5004, Range: &portRange},
+				Protos: []string{"RTP", "AVP"}, Formats: []string{"0"},
+			},
+			MediaTitle:            &information,
+			ConnectionInformation: &ConnectionInformation{Address: &Address{Address: "192.0.2.1"}},
+			Bandwidth:             []Bandwidth{{Type: "AS", Bandwidth: 32}},
+			EncryptionKey:         &key,
+			Attributes:            []Attribute{{Key: "sendonly"}},
+		}},
+	}
+	original, err := s.Marshal()
+	assert.NoError(t, err)
+
+	clone := s.Clone()
+	marsh, err := clone.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, original, marsh)
+	assert.Equal(t, s, clone)
+
+	*clone.SessionInformation = "changed"
+	clone.URI.Path = "/changed"
+	*clone.EmailAddress = "changed"
+	clone.ConnectionInformation.Address.Address = "224.2.1.2"
+	*clone.ConnectionInformation.Address.TTL = 1
+	*clone.ConnectionInformation.Address.Range = 1
+	clone.Bandwidth[0].Bandwidth = 1
+	clone.TimeDescriptions[0].RepeatTimes[0].Offsets[0] = 1
+	clone.TimeZones[0].Offset = 1
+	*clone.EncryptionKey = "changed"
+	clone.Attributes[0].Value = "changed"
+	md := clone.MediaDescriptions[0]
+	*md.MediaName.Port.Range = 1
+	md.MediaName.Protos[0] = "changed"
+	md.MediaName.Formats[0] = "8"
+	*md.MediaTitle = "changed"
+	md.ConnectionInformation.Address.Address = "192.0.2.2"
+	md.Bandwidth[0].Bandwidth = 1
+	*md.EncryptionKey = "changed"
+	md.Attributes[0].Key = "recvonly"
+
+	unchanged, err := s.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, original, unchanged)
+	assert.Equal(t, "https://user@example.com/session", s.URI.String())
+
+	assert.Nil(t, (*SessionDescription)(nil).Clone())
+	assert.Nil(t, (*MediaDescription)(nil).Clone())
+	assert.Nil(t, (*ConnectionInformation)(nil).Clone())
+	assert.Nil(t, (*Address)(nil).Clone())
+}
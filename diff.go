@@ -0,0 +1,456 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ChangeKind is the kind of a Change.
+type ChangeKind int
+
+const (
+	// ChangeAdded is a value that is only in the new description.
+	ChangeAdded ChangeKind = iota + 1
+	// ChangeRemoved is a value that is only in the old description.
+	ChangeRemoved
+	// ChangeModified is a value that differs between the descriptions.
+	ChangeModified
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case ChangeAdded:
+		return "added"
+	case ChangeRemoved:
+		return "removed"
+	case ChangeModified:
+		return "modified"
+	default:
+		return ""
+	}
+}
+
+// Fields of a Change, besides the types of the lines such as "o" or "c".
+const (
+	// DiffFieldAttribute is an attribute, Key is its key.
+	DiffFieldAttribute = "a"
+	// DiffFieldMedia, DiffFieldPort, DiffFieldProto and DiffFieldFormats
+	// are the parts of the m= line.
+	DiffFieldMedia   = "media"
+	DiffFieldPort    = "port"
+	DiffFieldProto   = "proto"
+	DiffFieldFormats = "formats"
+	// DiffFieldDirection is the effective direction of an m-section.
+	DiffFieldDirection = "direction"
+	// DiffFieldCodec is a codec of an m-section, Key is its payload type.
+	DiffFieldCodec = "codec"
+)
+
+const (
+	attrKeyRtpmap  = "rtpmap"
+	attrKeyFmtp    = "fmtp"
+	attrKeyRtcpFb  = "rtcp-fb"
+	diffIndentText = "  "
+)
+
+// Change is a change of a field of a session or media description.
+type Change struct {
+	Kind ChangeKind
+	// Field is the type of the line, such as "o" or "c", or one of the
+	// DiffField constants.
+	Field string
+	// Key distinguishes the values of a field, such as the key of an
+	// attribute or the payload type of a codec.
+	Key string
+	// Old and New are the values, Old is empty for an added value and New
+	// for a removed one.
+	Old, New string
+}
+
+// String renders the change on one line, prefixed with "+" for added, "-"
+// for removed and "~" for modified values.
+func (c Change) String() string {
+	name := c.Field
+	switch {
+	case c.Field == DiffFieldAttribute:
+		name += "=" + c.Key
+	case c.Key != "":
+		name += " " + c.Key
+	}
+
+	switch c.Kind {
+	case ChangeAdded:
+		return "+ " + name + ": " + c.New
+	case ChangeRemoved:
+		return "- " + name + ": " + c.Old
+	default:
+		return "~ " + name + ": " + c.Old + " -> " + c.New
+	}
+}
+
+// MediaDiff is the change of an m-section.
+type MediaDiff struct {
+	// Kind is ChangeAdded or ChangeRemoved for an m-section that is only in
+	// one of the descriptions, and ChangeModified otherwise.
+	Kind ChangeKind
+	MID  string
+	// OldIndex and NewIndex are the indexes of the m-section, -1 when it is
+	// not part of the description.
+	OldIndex, NewIndex int
+	// Changes are the changes of a modified m-section.
+	Changes []Change
+	// ICERestart is set when the effective ICE credentials of a modified
+	// m-section changed.
+	// https://datatracker.ietf.org/doc/html/rfc8839#section-4.4.1.1.1
+	ICERestart bool
+}
+
+// NewCandidates returns the candidates added to the m-section.
+func (d MediaDiff) NewCandidates() ([]ICECandidate, error) {
+	var candidates []ICECandidate
+	for _, c := range d.Changes {
+		if c.Field != DiffFieldAttribute || c.Key != AttrKeyCandidate || c.New == "" {
+			continue
+		}
+
+		var candidate ICECandidate
+		if err := candidate.Unmarshal(c.New); err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates, nil
+}
+
+// String renders the change of the m-section, one change per line.
+func (d MediaDiff) String() string {
+	var b strings.Builder
+	b.WriteString(d.Kind.String() + " m-section")
+	if d.MID != "" {
+		b.WriteString(" mid=" + d.MID)
+	}
+	switch d.Kind {
+	case ChangeAdded:
+		b.WriteString(" at " + strconv.Itoa(d.NewIndex))
+	case ChangeRemoved:
+		b.WriteString(" at " + strconv.Itoa(d.OldIndex))
+	default:
+		b.WriteString(" at " + strconv.Itoa(d.OldIndex))
+		if d.OldIndex != d.NewIndex {
+			b.WriteString(" -> " + strconv.Itoa(d.NewIndex))
+		}
+	}
+
+	if d.ICERestart {
+		b.WriteString("\n" + diffIndentText + "ICE restart")
+	}
+	for _, c := range d.Changes {
+		b.WriteString("\n" + diffIndentText + c.String())
+	}
+
+	return b.String()
+}
+
+// SessionDiff is the change between two session descriptions.
+type SessionDiff struct {
+	// Changes are the changes of the session-level fields.
+	Changes []Change
+	// Media are the m-sections that changed, in the order of the new
+	// description followed by the removed m-sections.
+	Media []MediaDiff
+}
+
+// IsEmpty reports whether the descriptions are equal.
+func (d *SessionDiff) IsEmpty() bool {
+	return len(d.Changes) == 0 && len(d.Media) == 0
+}
+
+// ICERestart reports whether the ICE credentials of any m-section changed.
+func (d *SessionDiff) ICERestart() bool {
+	return slices.ContainsFunc(d.Media, func(m MediaDiff) bool { return m.ICERestart })
+}
+
+// String renders the change set, one change per line. The changes of an
+// m-section are indented below it.
+func (d *SessionDiff) String() string {
+	lines := make([]string, 0, len(d.Changes)+len(d.Media))
+	for _, c := range d.Changes {
+		lines = append(lines, c.String())
+	}
+	for _, m := range d.Media {
+		lines = append(lines, m.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Diff returns the changes from one session description to another, such
+// as successive offers of a renegotiation. The m-sections are matched by
+// their mid, or by their index when they have none.
+//
+// Lines are compared in their marshaled form. The rtpmap, fmtp and rtcp-fb
+// attributes of an m-section are compared as codecs, and its direction
+// attributes as its effective direction. Values of a field that occurs
+// several times, such as candidates, are compared as a multiset: a single
+// changed value is reported as modified, others as added and removed.
+func Diff(from, to *SessionDescription) *SessionDiff {
+	diff := &SessionDiff{}
+	diff.Changes = diffLines(diff.Changes, from.marshalSessionInto(nil), to.marshalSessionInto(nil))
+	diff.Changes = diffAttributes(diff.Changes, from.Attributes, to.Attributes, false)
+
+	matched := make([]bool, len(from.MediaDescriptions))
+	for i, md := range to.MediaDescriptions {
+		j := matchMediaDescription(from, md, i)
+		if j < 0 || matched[j] {
+			diff.Media = append(diff.Media, MediaDiff{Kind: ChangeAdded, MID: mediaMID(md), OldIndex: -1, NewIndex: i})
+
+			continue
+		}
+		matched[j] = true
+
+		media := diffMediaDescription(from, to, from.MediaDescriptions[j], md)
+		if len(media.Changes) > 0 || media.ICERestart || i != j {
+			media.OldIndex, media.NewIndex = j, i
+			diff.Media = append(diff.Media, media)
+		}
+	}
+
+	for j, md := range from.MediaDescriptions {
+		if !matched[j] {
+			diff.Media = append(diff.Media, MediaDiff{Kind: ChangeRemoved, MID: mediaMID(md), OldIndex: j, NewIndex: -1})
+		}
+	}
+
+	return diff
+}
+
+func mediaMID(md *MediaDescription) string {
+	mid, _ := md.Attribute(AttrKeyMID)
+
+	return mid
+}
+
+// matchMediaDescription returns the index of the m-section of the session
+// that matches the m-section at the given index of the other session, or -1.
+func matchMediaDescription(s *SessionDescription, md *MediaDescription, index int) int {
+	if mid, ok := md.Attribute(AttrKeyMID); ok {
+		return slices.IndexFunc(s.MediaDescriptions, func(m *MediaDescription) bool {
+			value, ok := m.Attribute(AttrKeyMID)
+
+			return ok && value == mid
+		})
+	}
+
+	if index < len(s.MediaDescriptions) {
+		if _, ok := s.MediaDescriptions[index].Attribute(AttrKeyMID); !ok {
+			return index
+		}
+	}
+
+	return -1
+}
+
+func diffMediaDescription(fromSession, toSession *SessionDescription, from, to *MediaDescription) MediaDiff {
+	diff := MediaDiff{Kind: ChangeModified, MID: mediaMID(to)}
+
+	diff.Changes = diffValue(diff.Changes, DiffFieldMedia, "", from.MediaName.Media, to.MediaName.Media)
+	diff.Changes = diffValue(diff.Changes, DiffFieldPort, "",
+		from.MediaName.Port.String(), to.MediaName.Port.String())
+	diff.Changes = diffValue(diff.Changes, DiffFieldProto, "",
+		strings.Join(from.MediaName.Protos, "/"), strings.Join(to.MediaName.Protos, "/"))
+	diff.Changes = diffValue(diff.Changes, DiffFieldFormats, "",
+		strings.Join(from.MediaName.Formats, " "), strings.Join(to.MediaName.Formats, " "))
+	diff.Changes = diffValue(diff.Changes, DiffFieldDirection, "",
+		from.EffectiveDirection(fromSession).String(), to.EffectiveDirection(toSession).String())
+	diff.Changes = diffCodecs(diff.Changes, from.Codecs(), to.Codecs())
+	diff.Changes = diffLines(diff.Changes, from.marshalInto(nil), to.marshalInto(nil))
+	diff.Changes = diffAttributes(diff.Changes, from.Attributes, to.Attributes, true)
+
+	fromUfrag, fromPwd := iceCredentials(fromSession, from)
+	toUfrag, toPwd := iceCredentials(toSession, to)
+	diff.ICERestart = fromUfrag != "" && toUfrag != "" && (fromUfrag != toUfrag || fromPwd != toPwd)
+
+	return diff
+}
+
+// iceCredentials returns the ICE username fragment and password of an
+// m-section, which may be set at session level.
+func iceCredentials(s *SessionDescription, md *MediaDescription) (ufrag, pwd string) {
+	ufrag, _ = md.Attribute(attrKeyICEUfrag)
+	pwd, _ = md.Attribute(attrKeyICEPwd)
+	if ufrag != "" || pwd != "" {
+		return ufrag, pwd
+	}
+
+	ufrag, _ = s.Attribute(attrKeyICEUfrag)
+	pwd, _ = s.Attribute(attrKeyICEPwd)
+
+	return ufrag, pwd
+}
+
+func diffValue(changes []Change, field, key, from, to string) []Change {
+	switch {
+	case from == to:
+		return changes
+	case from == "":
+		return append(changes, Change{Kind: ChangeAdded, Field: field, Key: key, New: to})
+	case to == "":
+		return append(changes, Change{Kind: ChangeRemoved, Field: field, Key: key, Old: from})
+	default:
+		return append(changes, Change{Kind: ChangeModified, Field: field, Key: key, Old: from, New: to})
+	}
+}
+
+func diffCodecs(changes []Change, from, to []Codec) []Change {
+	payloadTypes := make([]uint8, 0, len(from)+len(to))
+	fromCodecs, toCodecs := map[uint8]string{}, map[uint8]string{}
+	for _, codecs := range []struct {
+		codecs []Codec
+		values map[uint8]string
+	}{{from, fromCodecs}, {to, toCodecs}} {
+		for _, codec := range codecs.codecs {
+			if !slices.Contains(payloadTypes, codec.PayloadType) {
+				payloadTypes = append(payloadTypes, codec.PayloadType)
+			}
+			codecs.values[codec.PayloadType] = codec.String()
+		}
+	}
+
+	for _, payloadType := range payloadTypes {
+		key := strconv.Itoa(int(payloadType))
+		changes = diffValue(changes, DiffFieldCodec, key, fromCodecs[payloadType], toCodecs[payloadType])
+	}
+
+	return changes
+}
+
+// groupedValues holds values grouped by a key, keeping the order of the
+// keys.
+type groupedValues struct {
+	keys   []string
+	values map[string][]string
+}
+
+func (g *groupedValues) add(key, value string) {
+	if g.values == nil {
+		g.values = map[string][]string{}
+	}
+	if _, ok := g.values[key]; !ok {
+		g.keys = append(g.keys, key)
+	}
+	g.values[key] = append(g.values[key], value)
+}
+
+// diffLines compares the marshaled lines of a section except for the
+// attribute and media lines, grouped by their type.
+func diffLines(changes []Change, from, to []byte) []Change {
+	group := func(text []byte) groupedValues {
+		var lines groupedValues
+		for line := range strings.SplitSeq(strings.TrimSuffix(string(text), "\r\n"), "\r\n") {
+			if len(line) < 2 || line[0] == 'a' || line[0] == 'm' {
+				continue
+			}
+			lines.add(line[:1], line[2:])
+		}
+
+		return lines
+	}
+
+	return diffGrouped(changes, group(from), group(to), func(key string) Change {
+		return Change{Field: key}
+	})
+}
+
+// diffAttributes compares attributes grouped by their key. The codec and
+// direction attributes of an m-section are left out.
+func diffAttributes(changes []Change, from, to []Attribute, media bool) []Change {
+	group := func(attributes []Attribute) groupedValues {
+		var values groupedValues
+		for _, a := range attributes {
+			if media {
+				if _, err := NewDirection(a.Key); err == nil {
+					continue
+				}
+				if a.Key == attrKeyRtpmap || a.Key == attrKeyFmtp || a.Key == attrKeyRtcpFb {
+					continue
+				}
+			}
+			values.add(a.Key, a.Value)
+		}
+
+		return values
+	}
+
+	return diffGrouped(changes, group(from), group(to), func(key string) Change {
+		return Change{Field: DiffFieldAttribute, Key: key}
+	})
+}
+
+func diffGrouped(changes []Change, from, to groupedValues, change func(key string) Change) []Change {
+	keys := slices.Clone(from.keys)
+	for _, key := range to.keys {
+		if _, ok := from.values[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	for _, key := range keys {
+		removed, added := diffMultiset(from.values[key], to.values[key])
+		if len(removed) == 1 && len(added) == 1 {
+			c := change(key)
+			c.Kind, c.Old, c.New = ChangeModified, removed[0], added[0]
+			changes = append(changes, c)
+
+			continue
+		}
+
+		for _, value := range removed {
+			c := change(key)
+			c.Kind, c.Old = ChangeRemoved, value
+			changes = append(changes, c)
+		}
+		for _, value := range added {
+			c := change(key)
+			c.Kind, c.New = ChangeAdded, value
+			changes = append(changes, c)
+		}
+	}
+
+	return changes
+}
+
+// diffMultiset returns the values that are only in from and only in to,
+// counting repeated values.
+func diffMultiset(from, to []string) (removed, added []string) {
+	count := func(values []string) map[string]int {
+		counts := map[string]int{}
+		for _, value := range values {
+			counts[value]++
+		}
+
+		return counts
+	}
+
+	fromCounts, toCounts := count(from), count(to)
+	for _, value := range from {
+		if toCounts[value] > 0 {
+			toCounts[value]--
+		} else {
+			removed = append(removed, value)
+		}
+	}
+	for _, value := range to {
+		if fromCounts[value] > 0 {
+			fromCounts[value]--
+		} else {
+			added = append(added, value)
+		}
+	}
+
+	return removed, added
+}
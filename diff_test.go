@@ -0,0 +1,158 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const diffOfferSDP = "v=0\r\n" +
+	"o=- 4215775240449105457 1 IN IP4 127.0.0.1\r\n" +
+	"s=-\r\n" +
+	"t=0 0\r\n" +
+	"a=group:BUNDLE 0 1\r\n" +
+	"m=audio 9 UDP/TLS/RTP/SAVPF 111 0\r\n" +
+	"c=IN IP4 0.0.0.0\r\n" +
+	"a=mid:0\r\n" +
+	"a=ice-ufrag:abcd\r\n" +
+	"a=ice-pwd:0123456789012345678901\r\n" +
+	"a=sendrecv\r\n" +
+	"a=rtpmap:111 opus/48000/2\r\n" +
+	"a=fmtp:111 minptime=10;useinbandfec=1\r\n" +
+	"a=candidate:1 1 udp 2130706431 192.0.2.1 5000 typ host\r\n" +
+	"m=video 9 UDP/TLS/RTP/SAVPF 96\r\n" +
+	"c=IN IP4 0.0.0.0\r\n" +
+	"a=mid:1\r\n" +
+	"a=ice-ufrag:abcd\r\n" +
+	"a=ice-pwd:0123456789012345678901\r\n" +
+	"a=sendrecv\r\n" +
+	"a=rtpmap:96 VP8/90000\r\n"
+
+func TestDiff(t *testing.T) {
+	var from, to SessionDescription
+	assert.NoError(t, from.UnmarshalString(diffOfferSDP))
+	assert.NoError(t, to.UnmarshalString(diffOfferSDP))
+
+	diff := Diff(&from, &to)
+	assert.True(t, diff.IsEmpty())
+	assert.Empty(t, diff.String())
+
+	to.Origin.SessionVersion = 2
+	to.Attributes[0].Value = "BUNDLE 0 1 2"
+	audio := to.MediaDescriptions[0]
+	audio.MediaName.Formats = []string{"111"}
+	audio.SetDirection(DirectionSendOnly)
+	audio.Attributes[5].Value = "111 minptime=10"
+	audio.WithCandidate("2 1 udp 1694498815 198.51.100.1 5000 typ srflx raddr 192.0.2.1 rport 5000")
+	video := to.MediaDescriptions[1]
+	video.Attributes[1].Value = "efgh"
+	video.Attributes[2].Value = "9876543210987654321098"
+	to.WithMedia((&MediaDescription{MediaName: MediaName{
+		Media:   "application",
+		Port:    RangedPort{Value: 9},
+		Protos:  []string{"UDP", "DTLS", "SCTP"},
+		Formats: []string{"webrtc-datachannel"},
+	}}).WithValueAttribute(AttrKeyMID, "2"))
+
+	diff = Diff(&from, &to)
+	assert.False(t, diff.IsEmpty())
+	assert.True(t, diff.ICERestart())
+	assert.Equal(t, []Change{
+		{Kind: ChangeModified, Field: "o", Old: "- 4215775240449105457 1 IN IP4 127.0.0.1",
+			New: "- 4215775240449105457 2 IN IP4 127.0.0.1"},
+		{Kind: ChangeModified, Field: DiffFieldAttribute, Key: AttrKeyGroup, Old: "BUNDLE 0 1", New: "BUNDLE 0 1 2"},
+	}, diff.Changes)
+	assert.Len(t, diff.Media, 3)
+
+	assert.Equal(t, MediaDiff{
+		Kind:     ChangeModified,
+		MID:      "0",
+		OldIndex: 0,
+		NewIndex: 0,
+		Changes: []Change{
+			{Kind: ChangeModified, Field: DiffFieldFormats, Old: "111 0", New: "111"},
+			{Kind: ChangeModified, Field: DiffFieldDirection, Old: "sendrecv", New: "sendonly"},
+			{Kind: ChangeModified, Field: DiffFieldCodec, Key: "111",
+				Old: "111 opus/48000/2 (minptime=10;useinbandfec=1) []", New: "111 opus/48000/2 (minptime=10) []"},
+			{Kind: ChangeRemoved, Field: DiffFieldCodec, Key: "0", Old: "0 PCMU/8000/ () []"},
+			{Kind: ChangeAdded, Field: DiffFieldAttribute, Key: AttrKeyCandidate,
+				New: "2 1 udp 1694498815 198.51.100.1 5000 typ srflx raddr 192.0.2.1 rport 5000"},
+		},
+	}, diff.Media[0])
+	candidates, err := diff.Media[0].NewCandidates()
+	assert.NoError(t, err)
+	assert.Len(t, candidates, 1)
+	assert.Equal(t, "srflx", candidates[0].Typ)
+
+	assert.True(t, diff.Media[1].ICERestart)
+	assert.Len(t, diff.Media[1].Changes, 2)
+	assert.Equal(t, MediaDiff{Kind: ChangeAdded, MID: "2", OldIndex: -1, NewIndex: 2}, diff.Media[2])
+
+	assert.Equal(t, "~ o: - 4215775240449105457 1 IN IP4 127.0.0.1 -> - 4215775240449105457 2 IN IP4 127.0.0.1\n"+
+		"~ a=group: BUNDLE 0 1 -> BUNDLE 0 1 2\n"+
+		"modified m-section mid=0 at 0\n"+
+		"  ~ formats: 111 0 -> 111\n"+
+		"  ~ direction: sendrecv -> sendonly\n"+
+		"  ~ codec 111: 111 opus/48000/2 (minptime=10;useinbandfec=1) [] -> 111 opus/48000/2 (minptime=10) []\n"+
+		"  - codec 0: 0 PCMU/8000/ () []\n"+
+		"  + a=candidate: 2 1 udp 1694498815 198.51.100.1 5000 typ srflx raddr 192.0.2.1 rport 5000\n"+
+		"modified m-section mid=1 at 1\n"+
+		"  ICE restart\n"+
+		"  ~ a=ice-ufrag: abcd -> efgh\n"+
+		"  ~ a=ice-pwd: 0123456789012345678901 -> 9876543210987654321098\n"+
+		"added m-section mid=2 at 2",
+		diff.String())
+
+	reverse := Diff(&to, &from)
+	assert.Equal(t, MediaDiff{Kind: ChangeRemoved, MID: "2", OldIndex: 2, NewIndex: -1}, reverse.Media[2])
+	assert.Equal(t, "removed m-section mid=2 at 2", reverse.Media[2].String())
+}
+
+func TestDiff_MatchByIndex(t *testing.T) {
+	from := (&SessionDescription{}).
+		WithMedia(&MediaDescription{MediaName: MediaName{Media: "audio", Port: RangedPort{Value: 5004}}}).
+		WithMedia(&MediaDescription{MediaName: MediaName{Media: "video", Port: RangedPort{Value: 5006}}})
+	to := (&SessionDescription{}).
+		WithMedia(&MediaDescription{MediaName: MediaName{Media: "audio", Port: RangedPort{Value: 0}}}).
+		WithValueAttribute(AttrKeySendOnly, "")
+	to.ConnectionInformation = &ConnectionInformation{
+		NetworkType: "IN", AddressType: "IP4", Address: &Address{Address: "192.0.2.1"},
+	}
+	to.Bandwidth = []Bandwidth{{Type: "AS", Bandwidth: 64}, {Type: "CT", Bandwidth: 128}}
+
+	diff := Diff(from, to)
+	assert.Equal(t, []Change{
+		{Kind: ChangeAdded, Field: "c", New: "IN IP4 192.0.2.1"},
+		{Kind: ChangeAdded, Field: "b", New: "AS:64"},
+		{Kind: ChangeAdded, Field: "b", New: "CT:128"},
+		{Kind: ChangeAdded, Field: DiffFieldAttribute, Key: AttrKeySendOnly},
+	}, diff.Changes)
+	assert.Equal(t, []MediaDiff{
+		{Kind: ChangeModified, OldIndex: 0, NewIndex: 0, Changes: []Change{
+			{Kind: ChangeModified, Field: DiffFieldPort, Old: "5004", New: "0"},
+			{Kind: ChangeModified, Field: DiffFieldDirection, Old: "sendrecv", New: "sendonly"},
+		}},
+		{Kind: ChangeRemoved, OldIndex: 1, NewIndex: -1},
+	}, diff.Media)
+	assert.False(t, diff.ICERestart())
+	assert.Equal(t, "removed m-section at 1", diff.Media[1].String())
+
+	// An m-section with a mid does not match one without.
+	to.MediaDescriptions[0].WithValueAttribute(AttrKeyMID, "0")
+	diff = Diff(from, to)
+	assert.Equal(t, ChangeAdded, diff.Media[0].Kind)
+	assert.Len(t, diff.Media, 3)
+}
+
+func TestDiffMultiset(t *testing.T) {
+	removed, added := diffMultiset([]string{"a", "b", "b", "c"}, []string{"b", "c", "c", "d"})
+	assert.Equal(t, []string{"a", "b"}, removed)
+	assert.Equal(t, []string{"c", "d"}, added)
+
+	removed, added = diffMultiset(nil, nil)
+	assert.Empty(t, removed)
+	assert.Empty(t, added)
+}
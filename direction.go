@@ -60,3 +60,150 @@ func (t Direction) String() string {
 		return directionUnknownStr
 	}
 }
+
+// Reverse returns the direction seen from the remote endpoint: sendonly
+// becomes recvonly and recvonly becomes sendonly.
+func (t Direction) Reverse() Direction {
+	switch t {
+	case DirectionSendOnly:
+		return DirectionRecvOnly
+	case DirectionRecvOnly:
+		return DirectionSendOnly
+	default:
+		return t
+	}
+}
+
+func (t Direction) sends() bool {
+	return t == DirectionSendRecv || t == DirectionSendOnly
+}
+
+func (t Direction) receives() bool {
+	return t == DirectionSendRecv || t == DirectionRecvOnly
+}
+
+// NegotiateDirection returns the direction of an answer to the offered
+// direction, restricted to the local preference. An unknown local preference
+// is treated as sendrecv.
+// https://datatracker.ietf.org/doc/html/rfc3264#section-6.1
+func NegotiateDirection(local, offered Direction) Direction {
+	if local == Direction(unknown) {
+		local = DirectionSendRecv
+	}
+
+	return directionFrom(offered.receives() && local.sends(), offered.sends() && local.receives())
+}
+
+// directionFrom returns the direction that sends and receives as given.
+func directionFrom(send, recv bool) Direction {
+	switch {
+	case send && recv:
+		return DirectionSendRecv
+	case send:
+		return DirectionSendOnly
+	case recv:
+		return DirectionRecvOnly
+	default:
+		return DirectionInactive
+	}
+}
+
+func directionFromAttributes(attributes []Attribute) (Direction, bool) {
+	for _, a := range attributes {
+		if direction, err := NewDirection(a.Key); err == nil {
+			return direction, true
+		}
+	}
+
+	return Direction(unknown), false
+}
+
+// EffectiveDirection returns the direction of the media description. When the
+// media description has no direction attribute, the session-level attribute
+// is used, and sendrecv when neither is present. session may be nil.
+// https://datatracker.ietf.org/doc/html/rfc4566#section-6
+func (d *MediaDescription) EffectiveDirection(session *SessionDescription) Direction {
+	if direction, ok := directionFromAttributes(d.Attributes); ok {
+		return direction
+	}
+
+	if session != nil {
+		if direction, ok := directionFromAttributes(session.Attributes); ok {
+			return direction
+		}
+	}
+
+	return DirectionSendRecv
+}
+
+// SetDirection replaces the direction attributes of the media description
+// with the given direction. The first direction attribute is updated in
+// place, otherwise a new attribute is appended.
+func (d *MediaDescription) SetDirection(direction Direction) {
+	replaced := false
+	attributes := d.Attributes[:0]
+	for _, a := range d.Attributes {
+		if _, err := NewDirection(a.Key); err == nil {
+			if replaced {
+				continue
+			}
+			a = NewPropertyAttribute(direction.String())
+			replaced = true
+		}
+		attributes = append(attributes, a)
+	}
+
+	d.Attributes = attributes
+	if !replaced {
+		d.WithPropertyAttribute(direction.String())
+	}
+}
+
+// IsOnHold reports whether the media description puts the stream on hold,
+// which is the case when its effective direction is sendonly or inactive.
+// https://datatracker.ietf.org/doc/html/rfc3264#section-8.4
+//
+// The legacy hold form of RFC 2543, a connection address of 0.0.0.0, is
+// detected as well. As ICE descriptions use 0.0.0.0 as a placeholder
+// address, the legacy form is ignored when ICE credentials are present.
+// session may be nil.
+func (d *MediaDescription) IsOnHold(session *SessionDescription) bool {
+	switch d.EffectiveDirection(session) {
+	case DirectionSendOnly, DirectionInactive:
+		return true
+	default:
+		return isLegacyHold(session, d)
+	}
+}
+
+// IsResumed reports whether the media description takes the stream off hold
+// compared to the previous description of the same stream.
+func (d *MediaDescription) IsResumed(
+	session *SessionDescription,
+	previousSession *SessionDescription,
+	previous *MediaDescription,
+) bool {
+	return previous != nil && previous.IsOnHold(previousSession) && !d.IsOnHold(session)
+}
+
+func isLegacyHold(session *SessionDescription, media *MediaDescription) bool {
+	connectionInformation := media.ConnectionInformation
+	if connectionInformation == nil && session != nil {
+		connectionInformation = session.ConnectionInformation
+	}
+	if connectionInformation == nil || connectionInformation.Address == nil ||
+		connectionInformation.Address.Address != "0.0.0.0" {
+		return false
+	}
+
+	if _, ok := media.Attribute(attrKeyICEUfrag); ok {
+		return false
+	}
+	if session != nil {
+		if _, ok := session.Attribute(attrKeyICEUfrag); ok {
+			return false
+		}
+	}
+
+	return true
+}
@@ -51,3 +51,73 @@ func TestDirection_String(t *testing.T) {
 		assert.Equalf(t, u.expected, u.actual.String(), "%d: %+v", i, u)
 	}
 }
+
+func TestDirection_Reverse(t *testing.T) {
+	assert.Equal(t, DirectionSendRecv, DirectionSendRecv.Reverse())
+	assert.Equal(t, DirectionRecvOnly, DirectionSendOnly.Reverse())
+	assert.Equal(t, DirectionSendOnly, DirectionRecvOnly.Reverse())
+	assert.Equal(t, DirectionInactive, DirectionInactive.Reverse())
+	assert.Equal(t, Direction(unknown), Direction(unknown).Reverse())
+}
+
+func TestNegotiateDirection(t *testing.T) {
+	for _, test := range []struct {
+		local, offered, expected Direction
+	}{
+		{Direction(unknown), DirectionSendRecv, DirectionSendRecv},
+		{DirectionSendOnly, DirectionSendRecv, DirectionSendOnly},
+		{DirectionSendRecv, DirectionSendOnly, DirectionRecvOnly},
+		{DirectionSendOnly, DirectionSendOnly, DirectionInactive},
+		{DirectionSendRecv, DirectionRecvOnly, DirectionSendOnly},
+		{DirectionRecvOnly, DirectionRecvOnly, DirectionInactive},
+		{DirectionSendRecv, DirectionInactive, DirectionInactive},
+	} {
+		assert.Equal(t, test.expected, NegotiateDirection(test.local, test.offered), "%+v", test)
+	}
+}
+
+func TestMediaDescription_EffectiveDirection(t *testing.T) {
+	session := &SessionDescription{Attributes: []Attribute{{Key: "recvonly"}}}
+	media := &MediaDescription{}
+
+	assert.Equal(t, DirectionSendRecv, media.EffectiveDirection(nil))
+	assert.Equal(t, DirectionRecvOnly, media.EffectiveDirection(session))
+
+	media.SetDirection(DirectionInactive)
+	assert.Equal(t, DirectionInactive, media.EffectiveDirection(session))
+
+	media.Attributes = []Attribute{{Key: "mid", Value: "0"}, {Key: "sendonly"}, {Key: "rtcp-mux"}, {Key: "sendrecv"}}
+	media.SetDirection(DirectionRecvOnly)
+	assert.Equal(t, []Attribute{{Key: "mid", Value: "0"}, {Key: "recvonly"}, {Key: "rtcp-mux"}}, media.Attributes)
+}
+
+func TestMediaDescription_IsOnHold(t *testing.T) {
+	legacy := &MediaDescription{
+		ConnectionInformation: &ConnectionInformation{
+			NetworkType: "IN",
+			AddressType: "IP4",
+			Address:     &Address{Address: "0.0.0.0"},
+		},
+	}
+	active := &MediaDescription{
+		ConnectionInformation: &ConnectionInformation{
+			NetworkType: "IN",
+			AddressType: "IP4",
+			Address:     &Address{Address: "192.0.2.1"},
+		},
+	}
+
+	assert.True(t, legacy.IsOnHold(nil))
+	assert.False(t, active.IsOnHold(nil))
+	assert.False(t, legacy.IsOnHold(&SessionDescription{Attributes: []Attribute{{Key: "ice-ufrag", Value: "a"}}}))
+	assert.True(t, active.IsOnHold(&SessionDescription{Attributes: []Attribute{{Key: "sendonly"}}}))
+	assert.False(t, (&MediaDescription{Attributes: []Attribute{{Key: "recvonly"}}}).IsOnHold(nil))
+	assert.True(t, (&MediaDescription{Attributes: []Attribute{{Key: "inactive"}}}).IsOnHold(nil))
+
+	session := &SessionDescription{ConnectionInformation: legacy.ConnectionInformation}
+	assert.True(t, (&MediaDescription{}).IsOnHold(session))
+
+	assert.True(t, active.IsResumed(nil, nil, legacy))
+	assert.False(t, legacy.IsResumed(nil, nil, active))
+	assert.False(t, active.IsResumed(nil, nil, nil))
+}
@@ -0,0 +1,321 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	// Register the hash functions of the supported fingerprints.
+	_ "crypto/sha1" //nolint:gosec
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+)
+
+// Hash functions of the "a=fingerprint" attribute that are supported by
+// Fingerprint. The md2 and md5 hash functions are not supported.
+// https://datatracker.ietf.org/doc/html/rfc8122#section-5
+const (
+	FingerprintHashSHA1   = "sha-1"
+	FingerprintHashSHA224 = "sha-224"
+	FingerprintHashSHA256 = "sha-256"
+	FingerprintHashSHA384 = "sha-384"
+	FingerprintHashSHA512 = "sha-512"
+)
+
+var (
+	errInvalidFingerprint      = errors.New("sdp: invalid fingerprint")
+	errUnsupportedHashFunction = errors.New("sdp: unsupported fingerprint hash function")
+	errNoFingerprint           = errors.New("sdp: no fingerprint")
+	errFingerprintMismatch     = errors.New("sdp: certificate does not match fingerprint")
+	errNegotiateConnectionRole = errors.New("sdp: cannot answer connection role")
+	errLocalConnectionRole     = errors.New("sdp: local connection role must be active or passive")
+	errMissingCertificate      = errors.New("sdp: no certificate")
+)
+
+// fingerprintHashes returns the supported hash functions, ordered from the
+// weakest to the strongest.
+func fingerprintHashes() []crypto.Hash {
+	return []crypto.Hash{crypto.SHA1, crypto.SHA224, crypto.SHA256, crypto.SHA384, crypto.SHA512}
+}
+
+// fingerprintHash returns the hash function and its strength for a hash
+// function name.
+func fingerprintHash(name string) (crypto.Hash, int, error) {
+	for i, hash := range fingerprintHashes() {
+		// crypto.Hash names the functions "SHA-256" and so on.
+		if strings.EqualFold(hash.String(), name) && hash.Available() {
+			return hash, i, nil
+		}
+	}
+
+	return 0, 0, fmt.Errorf("%w `%v`", errUnsupportedHashFunction, name)
+}
+
+// Fingerprint represents the value of an "a=fingerprint" attribute, the hash
+// of the certificate used in the DTLS handshake.
+//
+//	fingerprint-attribute = "fingerprint" ":" hash-func SP fingerprint
+//	fingerprint           = 2UHEX *(":" 2UHEX)
+//
+// https://datatracker.ietf.org/doc/html/rfc8122#section-5
+type Fingerprint struct {
+	// HashFunction is the lowercase name of the hash function, such as
+	// FingerprintHashSHA256.
+	HashFunction string
+	Value        []byte
+}
+
+// NewFingerprint computes the fingerprint of a certificate with the given
+// hash function.
+func NewFingerprint(cert *x509.Certificate, hashFunction string) (Fingerprint, error) {
+	if cert == nil {
+		return Fingerprint{}, errMissingCertificate
+	}
+
+	hash, _, err := fingerprintHash(hashFunction)
+	if err != nil {
+		return Fingerprint{}, err
+	}
+
+	h := hash.New()
+	h.Write(cert.Raw)
+
+	return Fingerprint{HashFunction: strings.ToLower(hashFunction), Value: h.Sum(nil)}, nil
+}
+
+// Unmarshal creates a Fingerprint from a string. The "a=" and "fingerprint:"
+// prefixes are optional. The hash function must be supported and the value
+// must have the length of its digest.
+func (f *Fingerprint) Unmarshal(raw string) error {
+	raw = strings.TrimPrefix(strings.TrimSpace(raw), attributeKey)
+	raw = strings.TrimPrefix(raw, AttrKeyFingerprint+":")
+
+	fields := strings.Fields(raw)
+	if len(fields) != 2 {
+		return fmt.Errorf("%w: %v", errInvalidFingerprint, raw)
+	}
+
+	hash, _, err := fingerprintHash(fields[0])
+	if err != nil {
+		return err
+	}
+
+	octets := strings.Split(fields[1], ":")
+	if len(octets) != hash.Size() {
+		return fmt.Errorf("%w: %v", errInvalidFingerprint, fields[1])
+	}
+
+	value := make([]byte, 0, len(octets))
+	for _, octet := range octets {
+		b, err := hex.DecodeString(octet)
+		if err != nil || len(b) != 1 {
+			return fmt.Errorf("%w: %v", errInvalidFingerprint, fields[1])
+		}
+		value = append(value, b[0])
+	}
+
+	f.HashFunction = strings.ToLower(fields[0])
+	f.Value = value
+
+	return nil
+}
+
+// Marshal creates a string from a Fingerprint.
+func (f Fingerprint) Marshal() string {
+	return AttrKeyFingerprint + ":" + f.String()
+}
+
+// String returns the value of the "a=fingerprint" attribute, with the
+// fingerprint in uppercase hexadecimal.
+func (f Fingerprint) String() string {
+	return stringFromMarshal(f.marshalInto, f.marshalSize)
+}
+
+func (f Fingerprint) marshalInto(b []byte) []byte {
+	const digits = "0123456789ABCDEF"
+
+	b = append(append(b, f.HashFunction...), ' ')
+	for i, octet := range f.Value {
+		if i > 0 {
+			b = append(b, ':')
+		}
+		b = append(b, digits[octet>>4], digits[octet&0x0f])
+	}
+
+	return b
+}
+
+func (f Fingerprint) marshalSize() int {
+	size := len(f.HashFunction) + 1
+	if len(f.Value) > 0 {
+		size += len(f.Value)*3 - 1
+	}
+
+	return size
+}
+
+// Matches reports whether the certificate has this fingerprint.
+func (f Fingerprint) Matches(cert *x509.Certificate) bool {
+	actual, err := NewFingerprint(cert, f.HashFunction)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(actual.Value, f.Value)
+}
+
+// Fingerprints parses and returns the session-level "a=fingerprint"
+// attributes.
+func (s *SessionDescription) Fingerprints() ([]Fingerprint, error) {
+	return fingerprintsFromAttributes(s.Attributes)
+}
+
+// Fingerprints parses and returns the media-level "a=fingerprint" attributes.
+// The session-level fingerprints apply when there are none, see
+// MatchCertificate.
+func (d *MediaDescription) Fingerprints() ([]Fingerprint, error) {
+	return fingerprintsFromAttributes(d.Attributes)
+}
+
+func fingerprintsFromAttributes(attributes []Attribute) ([]Fingerprint, error) {
+	var fingerprints []Fingerprint
+	for _, a := range attributes {
+		if a.Key != AttrKeyFingerprint {
+			continue
+		}
+
+		var fingerprint Fingerprint
+		if err := fingerprint.Unmarshal(a.Value); err != nil {
+			return nil, err
+		}
+		fingerprints = append(fingerprints, fingerprint)
+	}
+
+	return fingerprints, nil
+}
+
+// MatchCertificate checks a certificate presented in the DTLS handshake
+// against the session-level fingerprints.
+func (s *SessionDescription) MatchCertificate(cert *x509.Certificate) error {
+	return matchCertificate(s.Attributes, cert)
+}
+
+// MatchCertificate checks a certificate presented in the DTLS handshake
+// against the fingerprints of the media description. Media-level
+// fingerprints take precedence over the session-level ones.
+//
+// Fingerprints with an unsupported hash function are ignored. When several
+// hash functions are used, the certificate is only checked against the
+// fingerprints of the strongest one, and it matches if any of those
+// fingerprints matches.
+// https://datatracker.ietf.org/doc/html/rfc8122#section-5
+func (d *MediaDescription) MatchCertificate(session *SessionDescription, cert *x509.Certificate) error {
+	if slices.ContainsFunc(d.Attributes, func(a Attribute) bool { return a.Key == AttrKeyFingerprint }) ||
+		session == nil {
+		return matchCertificate(d.Attributes, cert)
+	}
+
+	return matchCertificate(session.Attributes, cert)
+}
+
+func matchCertificate(attributes []Attribute, cert *x509.Certificate) error {
+	if cert == nil {
+		return errMissingCertificate
+	}
+
+	var candidates []Fingerprint
+	strongest := -1
+	for _, a := range attributes {
+		if a.Key != AttrKeyFingerprint {
+			continue
+		}
+
+		var fingerprint Fingerprint
+		err := fingerprint.Unmarshal(a.Value)
+		switch {
+		case errors.Is(err, errUnsupportedHashFunction):
+			continue
+		case err != nil:
+			return err
+		}
+
+		_, strength, _ := fingerprintHash(fingerprint.HashFunction)
+		if strength > strongest {
+			strongest, candidates = strength, nil
+		}
+		if strength == strongest {
+			candidates = append(candidates, fingerprint)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return errNoFingerprint
+	}
+
+	for _, fingerprint := range candidates {
+		if fingerprint.Matches(cert) {
+			return nil
+		}
+	}
+
+	return errFingerprintMismatch
+}
+
+// EffectiveConnectionRole returns the "a=setup" role of the media
+// description, falling back to the session-level attribute. ConnectionRoleActive
+// is returned when neither is present.
+// https://datatracker.ietf.org/doc/html/rfc4145#section-4
+func (d *MediaDescription) EffectiveConnectionRole(session *SessionDescription) (ConnectionRole, error) {
+	if value, ok := d.Attribute(AttrKeyConnectionSetup); ok {
+		return NewConnectionRole(value)
+	}
+	if session != nil {
+		if value, ok := session.Attribute(AttrKeyConnectionSetup); ok {
+			return NewConnectionRole(value)
+		}
+	}
+
+	return ConnectionRoleActive, nil
+}
+
+// WithConnectionRole adds an "a=setup" attribute to the media description.
+func (d *MediaDescription) WithConnectionRole(role ConnectionRole) *MediaDescription {
+	return d.WithValueAttribute(AttrKeyConnectionSetup, role.String())
+}
+
+// NegotiateConnectionRole returns the role of an answer to the offered role.
+// An offered actpass is answered with the local preference, which must be
+// active or passive, or zero to default to active as recommended by
+// RFC 5763. Any other local role is an error. An offered active or passive
+// role is answered with the opposite role, and holdconn with holdconn.
+// https://datatracker.ietf.org/doc/html/rfc5763#section-5
+func NegotiateConnectionRole(local, offered ConnectionRole) (ConnectionRole, error) {
+	if local != ConnectionRole(unknown) && local != ConnectionRoleActive && local != ConnectionRolePassive {
+		return ConnectionRole(unknown), fmt.Errorf("%w: %v", errLocalConnectionRole, local)
+	}
+
+	switch offered {
+	case ConnectionRoleActpass:
+		if local == ConnectionRolePassive {
+			return ConnectionRolePassive, nil
+		}
+
+		return ConnectionRoleActive, nil
+	case ConnectionRoleActive:
+		return ConnectionRolePassive, nil
+	case ConnectionRolePassive:
+		return ConnectionRoleActive, nil
+	case ConnectionRoleHoldconn:
+		return ConnectionRoleHoldconn, nil
+	default:
+		return ConnectionRole(unknown), fmt.Errorf("%w: %v", errNegotiateConnectionRole, offered)
+	}
+}
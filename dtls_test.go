@@ -0,0 +1,190 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestCertificate(t *testing.T) *x509.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	raw, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(raw)
+	assert.NoError(t, err)
+
+	return cert
+}
+
+func TestFingerprint_Unmarshal(t *testing.T) {
+	raw := "sha-256 19:E2:1C:3B:4B:9F:81:E6:B8:5C:F4:A5:A8:D8:73:04:BB:05:2F:70:9F:04:A9:0E:05:E9:26:33:E8:70:88:A2"
+
+	for _, value := range []string{raw, "a=fingerprint:" + raw, "fingerprint:" + raw} {
+		var fingerprint Fingerprint
+		assert.NoError(t, fingerprint.Unmarshal(value))
+		assert.Equal(t, FingerprintHashSHA256, fingerprint.HashFunction)
+		assert.Len(t, fingerprint.Value, 32)
+		assert.Equal(t, raw, fingerprint.String())
+		assert.Equal(t, "fingerprint:"+raw, fingerprint.Marshal())
+	}
+
+	var fingerprint Fingerprint
+	assert.NoError(t, fingerprint.Unmarshal("SHA-1 4a:ad:b9:b1:3f:82:18:3b:54:02:12:df:3e:5d:49:6b:19:e5:7c:ab"))
+	assert.Equal(t, "sha-1 4A:AD:B9:B1:3F:82:18:3B:54:02:12:DF:3E:5D:49:6B:19:E5:7C:AB", fingerprint.String())
+
+	for _, value := range []string{
+		"",
+		"sha-256",
+		"md5 4A:AD:B9:B1:3F:82:18:3B:54:02:12:DF:3E:5D:49:6B",
+		"sha-256 4A:AD",
+		"sha-1 4A:AD:B9:B1:3F:82:18:3B:54:02:12:DF:3E:5D:49:6B:19:E5:7C:XY",
+		"sha-1 4A:AD:B9:B1:3F:82:18:3B:54:02:12:DF:3E:5D:49:6B:19:E5:7C:ABC",
+	} {
+		assert.Error(t, fingerprint.Unmarshal(value), value)
+	}
+	assert.ErrorIs(t, fingerprint.Unmarshal("md5 4A:AD"), errUnsupportedHashFunction)
+}
+
+func TestNewFingerprint(t *testing.T) {
+	cert := newTestCertificate(t)
+
+	fingerprint, err := NewFingerprint(cert, "SHA-256")
+	assert.NoError(t, err)
+	digest := sha256.Sum256(cert.Raw)
+	assert.Equal(t, Fingerprint{HashFunction: FingerprintHashSHA256, Value: digest[:]}, fingerprint)
+	assert.True(t, fingerprint.Matches(cert))
+	assert.False(t, fingerprint.Matches(newTestCertificate(t)))
+
+	var parsed Fingerprint
+	assert.NoError(t, parsed.Unmarshal(fingerprint.Marshal()))
+	assert.Equal(t, fingerprint, parsed)
+
+	_, err = NewFingerprint(cert, "md5")
+	assert.ErrorIs(t, err, errUnsupportedHashFunction)
+	_, err = NewFingerprint(nil, FingerprintHashSHA256)
+	assert.ErrorIs(t, err, errMissingCertificate)
+}
+
+func TestMatchCertificate(t *testing.T) {
+	cert, other := newTestCertificate(t), newTestCertificate(t)
+	sha256Fingerprint, err := NewFingerprint(cert, FingerprintHashSHA256)
+	assert.NoError(t, err)
+	sha1Fingerprint, err := NewFingerprint(cert, FingerprintHashSHA1)
+	assert.NoError(t, err)
+	otherFingerprint, err := NewFingerprint(other, FingerprintHashSHA512)
+	assert.NoError(t, err)
+
+	session := (&SessionDescription{}).
+		WithValueAttribute(AttrKeyFingerprint, sha256Fingerprint.String())
+	media := &MediaDescription{}
+
+	assert.NoError(t, session.MatchCertificate(cert))
+	assert.ErrorIs(t, session.MatchCertificate(other), errFingerprintMismatch)
+	assert.ErrorIs(t, session.MatchCertificate(nil), errMissingCertificate)
+	assert.NoError(t, media.MatchCertificate(session, cert))
+	assert.ErrorIs(t, media.MatchCertificate(nil, cert), errNoFingerprint)
+
+	fingerprints, err := session.Fingerprints()
+	assert.NoError(t, err)
+	assert.Equal(t, []Fingerprint{sha256Fingerprint}, fingerprints)
+
+	// Media-level fingerprints override the session-level ones, and only the
+	// strongest hash function is checked.
+	media.WithValueAttribute(AttrKeyFingerprint, "md5 4A:AD").
+		WithValueAttribute(AttrKeyFingerprint, sha1Fingerprint.String())
+	assert.NoError(t, media.MatchCertificate(session, cert))
+	media.WithValueAttribute(AttrKeyFingerprint, otherFingerprint.String())
+	assert.ErrorIs(t, media.MatchCertificate(session, cert), errFingerprintMismatch)
+	assert.NoError(t, media.MatchCertificate(session, other))
+
+	_, err = media.Fingerprints()
+	assert.ErrorIs(t, err, errUnsupportedHashFunction)
+
+	media.WithValueAttribute(AttrKeyFingerprint, "sha-256 XX")
+	assert.ErrorIs(t, media.MatchCertificate(session, other), errInvalidFingerprint)
+}
+
+func TestNewConnectionRole(t *testing.T) {
+	for _, role := range []ConnectionRole{
+		ConnectionRoleActive,
+		ConnectionRolePassive,
+		ConnectionRoleActpass,
+		ConnectionRoleHoldconn,
+	} {
+		parsed, err := NewConnectionRole(role.String())
+		assert.NoError(t, err)
+		assert.Equal(t, role, parsed)
+	}
+
+	_, err := NewConnectionRole("Active")
+	assert.ErrorIs(t, err, errConnectionRole)
+}
+
+func TestEffectiveConnectionRole(t *testing.T) {
+	session := &SessionDescription{}
+	media := &MediaDescription{}
+
+	role, err := media.EffectiveConnectionRole(session)
+	assert.NoError(t, err)
+	assert.Equal(t, ConnectionRoleActive, role)
+
+	session.WithValueAttribute(AttrKeyConnectionSetup, "passive")
+	role, err = media.EffectiveConnectionRole(session)
+	assert.NoError(t, err)
+	assert.Equal(t, ConnectionRolePassive, role)
+
+	media.WithConnectionRole(ConnectionRoleActpass)
+	role, err = media.EffectiveConnectionRole(session)
+	assert.NoError(t, err)
+	assert.Equal(t, ConnectionRoleActpass, role)
+
+	_, err = (&MediaDescription{}).WithValueAttribute(AttrKeyConnectionSetup, "x").EffectiveConnectionRole(nil)
+	assert.ErrorIs(t, err, errConnectionRole)
+}
+
+func TestNegotiateConnectionRole(t *testing.T) {
+	for _, test := range []struct {
+		local, offered, expected ConnectionRole
+	}{
+		{ConnectionRole(unknown), ConnectionRoleActpass, ConnectionRoleActive},
+		{ConnectionRoleActive, ConnectionRoleActpass, ConnectionRoleActive},
+		{ConnectionRolePassive, ConnectionRoleActpass, ConnectionRolePassive},
+		{ConnectionRolePassive, ConnectionRoleActive, ConnectionRolePassive},
+		{ConnectionRolePassive, ConnectionRolePassive, ConnectionRoleActive},
+		{ConnectionRoleActive, ConnectionRoleHoldconn, ConnectionRoleHoldconn},
+	} {
+		role, err := NegotiateConnectionRole(test.local, test.offered)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, role, "%v %v", test.local, test.offered)
+	}
+
+	_, err := NegotiateConnectionRole(ConnectionRoleActive, ConnectionRole(unknown))
+	assert.ErrorIs(t, err, errNegotiateConnectionRole)
+
+	for _, local := range []ConnectionRole{ConnectionRoleActpass, ConnectionRoleHoldconn, ConnectionRole(42)} {
+		_, err = NegotiateConnectionRole(local, ConnectionRoleActpass)
+		assert.ErrorIs(t, err, errLocalConnectionRole, "%v", local)
+		_, err = NegotiateConnectionRole(local, ConnectionRoleActive)
+		assert.ErrorIs(t, err, errLocalConnectionRole, "%v", local)
+	}
+}
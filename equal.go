@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"bytes"
+	"strings"
+)
+
+// EqualOptions selects the differences that Equal ignores.
+type EqualOptions struct {
+	// IgnoreAttributeOrder compares the attributes of the session and of
+	// each media description regardless of their order.
+	IgnoreAttributeOrder bool
+
+	// IgnoreSessionVersion ignores the session version of the o= line,
+	// which changes with every offer of a session.
+	IgnoreSessionVersion bool
+
+	// IgnoreCodecNameCase compares the encoding names of rtpmap attributes
+	// case-insensitively.
+	// https://datatracker.ietf.org/doc/html/rfc4855#section-3
+	IgnoreCodecNameCase bool
+
+	// IgnoreFmtpOrder ignores the order of the parameters of fmtp
+	// attributes.
+	IgnoreFmtpOrder bool
+}
+
+// Equal reports whether two session descriptions are equal. Lines are
+// compared in their marshaled form, with the differences selected by the
+// options ignored. The media descriptions are compared in order.
+func (s *SessionDescription) Equal(other *SessionDescription, options EqualOptions) bool {
+	if s == nil || other == nil {
+		return s == other
+	}
+
+	a, b := *s, *other
+	a.Attributes, b.Attributes = nil, nil
+	if options.IgnoreSessionVersion {
+		a.Origin.SessionVersion, b.Origin.SessionVersion = 0, 0
+	}
+
+	if !bytes.Equal(a.marshalSessionInto(nil), b.marshalSessionInto(nil)) ||
+		!attributesEqual(s.Attributes, other.Attributes, options) ||
+		len(s.MediaDescriptions) != len(other.MediaDescriptions) {
+		return false
+	}
+
+	for i, md := range s.MediaDescriptions {
+		if !md.Equal(other.MediaDescriptions[i], options) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equal reports whether two media descriptions are equal, see
+// SessionDescription.Equal.
+func (d *MediaDescription) Equal(other *MediaDescription, options EqualOptions) bool {
+	if d == nil || other == nil {
+		return d == other
+	}
+
+	a, b := *d, *other
+	a.Attributes, b.Attributes = nil, nil
+
+	return bytes.Equal(a.marshalInto(nil), b.marshalInto(nil)) &&
+		attributesEqual(d.Attributes, other.Attributes, options)
+}
+
+func attributesEqual(a, b []Attribute, options EqualOptions) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	if !options.IgnoreAttributeOrder {
+		for i := range a {
+			if !attributeEqual(a[i], b[i], options) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	// Attribute equality is an equivalence, so matching each attribute
+	// with the first equal one that is left is enough.
+	matched := make([]bool, len(b))
+	for _, attribute := range a {
+		found := false
+		for j := range b {
+			if !matched[j] && attributeEqual(attribute, b[j], options) {
+				matched[j], found = true, true
+
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func attributeEqual(a, b Attribute, options EqualOptions) bool {
+	if a.Key != b.Key {
+		return false
+	}
+
+	switch {
+	case a.Key == attrKeyRtpmap && options.IgnoreCodecNameCase:
+		// Apart from the encoding name, rtpmap values are numeric.
+		return strings.EqualFold(a.Value, b.Value)
+	case a.Key == attrKeyFmtp && options.IgnoreFmtpOrder:
+		payloadTypeA, parametersA, _ := strings.Cut(a.Value, " ")
+		payloadTypeB, parametersB, _ := strings.Cut(b.Value, " ")
+
+		return payloadTypeA == payloadTypeB && equivalentFmtp(parametersA, parametersB)
+	default:
+		return a.Value == b.Value
+	}
+}
@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const equalSDP = "v=0\r\n" +
+	"o=- 4215775240449105457 1 IN IP4 127.0.0.1\r\n" +
+	"s=-\r\n" +
+	"t=0 0\r\n" +
+	"a=group:BUNDLE 0\r\n" +
+	"a=ice-lite\r\n" +
+	"m=video 9 UDP/TLS/RTP/SAVPF 96\r\n" +
+	"c=IN IP4 0.0.0.0\r\n" +
+	"a=mid:0\r\n" +
+	"a=rtpmap:96 H264/90000\r\n" +
+	"a=fmtp:96 packetization-mode=1;profile-level-id=42e01f\r\n"
+
+func TestSessionDescription_Equal(t *testing.T) {
+	var s SessionDescription
+	assert.NoError(t, s.UnmarshalString(equalSDP))
+
+	all := EqualOptions{
+		IgnoreAttributeOrder: true,
+		IgnoreSessionVersion: true,
+		IgnoreCodecNameCase:  true,
+		IgnoreFmtpOrder:      true,
+	}
+
+	for _, test := range []struct {
+		name    string
+		modify  func(*SessionDescription)
+		options EqualOptions
+	}{
+		{"session version", func(s *SessionDescription) {
+			s.Origin.SessionVersion = 2
+		}, EqualOptions{IgnoreSessionVersion: true}},
+		{"session attribute order", func(s *SessionDescription) {
+			s.Attributes[0], s.Attributes[1] = s.Attributes[1], s.Attributes[0]
+		}, EqualOptions{IgnoreAttributeOrder: true}},
+		{"media attribute order", func(s *SessionDescription) {
+			attributes := s.MediaDescriptions[0].Attributes
+			attributes[0], attributes[2] = attributes[2], attributes[0]
+		}, EqualOptions{IgnoreAttributeOrder: true}},
+		{"codec name case", func(s *SessionDescription) {
+			s.MediaDescriptions[0].Attributes[1].Value = "96 h264/90000"
+		}, EqualOptions{IgnoreCodecNameCase: true}},
+		{"fmtp order", func(s *SessionDescription) {
+			s.MediaDescriptions[0].Attributes[2].Value = "96 profile-level-id=42e01f;packetization-mode=1"
+		}, EqualOptions{IgnoreFmtpOrder: true}},
+	} {
+		other := s.Clone()
+		assert.True(t, s.Equal(other, EqualOptions{}), test.name)
+
+		test.modify(other)
+		assert.False(t, s.Equal(other, EqualOptions{}), test.name)
+		assert.True(t, s.Equal(other, test.options), test.name)
+		assert.True(t, other.Equal(&s, all), test.name)
+	}
+
+	for _, test := range []struct {
+		name   string
+		modify func(*SessionDescription)
+	}{
+		{"session name", func(s *SessionDescription) { s.SessionName = "x" }},
+		{"session attribute", func(s *SessionDescription) { s.Attributes[1].Key = "ice-options" }},
+		{"missing attribute", func(s *SessionDescription) { s.Attributes = s.Attributes[:1] }},
+		{"media count", func(s *SessionDescription) { s.MediaDescriptions = nil }},
+		{"port", func(s *SessionDescription) { s.MediaDescriptions[0].MediaName.Port.Value = 0 }},
+		{"connection", func(s *SessionDescription) {
+			s.MediaDescriptions[0].ConnectionInformation.Address.Address = "192.0.2.1"
+		}},
+		{"codec", func(s *SessionDescription) { s.MediaDescriptions[0].Attributes[1].Value = "96 VP8/90000" }},
+		{"fmtp payload type", func(s *SessionDescription) {
+			s.MediaDescriptions[0].Attributes[2].Value = "97 packetization-mode=1;profile-level-id=42e01f"
+		}},
+		{"fmtp value", func(s *SessionDescription) {
+			s.MediaDescriptions[0].Attributes[2].Value = "96 packetization-mode=0;profile-level-id=42e01f"
+		}},
+		{"duplicate attribute", func(s *SessionDescription) {
+			s.MediaDescriptions[0].Attributes[0] = s.MediaDescriptions[0].Attributes[1]
+		}},
+	} {
+		other := s.Clone()
+		test.modify(other)
+		assert.False(t, s.Equal(other, all), test.name)
+	}
+
+	assert.True(t, (*SessionDescription)(nil).Equal(nil, all))
+	assert.False(t, s.Equal(nil, all))
+	assert.False(t, (*MediaDescription)(nil).Equal(s.MediaDescriptions[0], all))
+}
@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ParseError is returned by UnmarshalString when the session description
+// cannot be parsed. It locates the offending line and wraps the cause, which
+// can be matched with errors.Is against ErrSDPInvalidSyntax,
+// ErrSDPInvalidNumericValue, ErrSDPInvalidValue, ErrSDPInvalidPortValue and
+// ErrSDPUnexpectedEOF.
+type ParseError struct {
+	// Line is the 1-based number of the offending line.
+	Line int
+	// Column is the 1-based byte offset within the line of the offending
+	// field.
+	Column int
+	// LineType is the type of the offending line, such as 'v', 'm' or 'a'.
+	LineType byte
+	// Text is the offending line without its line ending.
+	Text string
+	// Err is the cause of the failure.
+	Err error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("sdp: line %d column %d: %v: %q", e.Line, e.Column, e.Err, e.Text)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// parseError wraps an error returned by a state function into a ParseError
+// located at the line being parsed.
+func (l *lexer) parseError(err error) error {
+	return l.newParseError(err)
+}
+
+func (l *lexer) newParseError(err error) *ParseError {
+	if errors.Is(err, io.EOF) {
+		err = fmt.Errorf("%w: %w", ErrSDPUnexpectedEOF, err)
+	}
+
+	start := min(l.lineStart, len(l.value))
+	text := l.value[start:]
+	if i := strings.IndexAny(text, "\r\n"); i >= 0 {
+		text = text[:i]
+	}
+
+	offset := l.fieldStart
+	var syntaxErr syntaxError
+	if errors.As(err, &syntaxErr) {
+		offset = syntaxErr.i
+	}
+
+	parseErr := &ParseError{
+		Line:   strings.Count(l.value[:start], "\n") + 1,
+		Column: max(offset-start, 0) + 1,
+		Text:   text,
+		Err:    err,
+	}
+	if len(text) > 0 {
+		parseErr.LineType = text[0]
+	}
+
+	return parseErr
+}
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseError(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		sdp      string
+		expected ParseError
+		cause    error
+	}{
+		{
+			name: "invalid version",
+			sdp:  "v=1\r\n",
+			expected: ParseError{
+				Line: 1, Column: 3, LineType: 'v', Text: "v=1",
+			},
+			cause: ErrSDPInvalidValue,
+		},
+		{
+			name: "invalid network type",
+			sdp:  "v=0\r\no=jdoe 2890844526 2890842807 XX IP4 10.47.16.5\r\n",
+			expected: ParseError{
+				Line: 2, Column: 30, LineType: 'o', Text: "o=jdoe 2890844526 2890842807 XX IP4 10.47.16.5",
+			},
+			cause: ErrSDPInvalidValue,
+		},
+		{
+			name: "non numeric session id",
+			sdp:  "v=0\no=jdoe 28x 1 IN IP4 10.47.16.5\n",
+			expected: ParseError{
+				Line: 2, Column: 10, LineType: 'o', Text: "o=jdoe 28x 1 IN IP4 10.47.16.5",
+			},
+			cause: ErrSDPInvalidSyntax,
+		},
+		{
+			name: "unexpected line type",
+			sdp:  TimingSDP + "\r\nx=unknown\r\n",
+			expected: ParseError{
+				Line: 6, Column: 1, LineType: 'x', Text: "x=unknown",
+			},
+			cause: ErrSDPInvalidSyntax,
+		},
+		{
+			name: "invalid port",
+			sdp:  TimingSDP + "m=audio 99999 RTP/AVP 0\r\n",
+			expected: ParseError{
+				Line: 5, Column: 9, LineType: 'm', Text: "m=audio 99999 RTP/AVP 0",
+			},
+			cause: ErrSDPInvalidPortValue,
+		},
+		{
+			name: "unknown proto",
+			sdp:  TimingSDP + "m=audio 9 RTP/FOO 0\r\n",
+			expected: ParseError{
+				Line: 5, Column: 11, LineType: 'm', Text: "m=audio 9 RTP/FOO 0",
+			},
+			cause: ErrSDPInvalidValue,
+		},
+		{
+			name: "invalid session bandwidth",
+			sdp:  "v=0\r\no=- 1 1 IN IP4 127.0.0.1\r\ns=-\r\nb=AS\r\n",
+			expected: ParseError{
+				Line: 4, Column: 3, LineType: 'b', Text: "b=AS",
+			},
+			cause: ErrSDPInvalidValue,
+		},
+		{
+			name: "invalid media bandwidth",
+			sdp:  TimingSDP + "m=audio 9 RTP/AVP 0\r\nb=AS\r\n",
+			expected: ParseError{
+				Line: 6, Column: 3, LineType: 'b', Text: "b=AS",
+			},
+			cause: ErrSDPInvalidValue,
+		},
+		{
+			name: "truncated",
+			sdp:  "v=0\r\no=jdoe 2890844526",
+			expected: ParseError{
+				Line: 2, Column: 18, LineType: 'o', Text: "o=jdoe 2890844526",
+			},
+			cause: ErrSDPUnexpectedEOF,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var sd SessionDescription
+			err := sd.UnmarshalString(test.sdp)
+
+			var parseErr *ParseError
+			if assert.ErrorAs(t, err, &parseErr) {
+				assert.Equal(t, test.expected.Line, parseErr.Line)
+				assert.Equal(t, test.expected.Column, parseErr.Column)
+				assert.Equal(t, test.expected.LineType, parseErr.LineType)
+				assert.Equal(t, test.expected.Text, parseErr.Text)
+			}
+			assert.ErrorIs(t, err, test.cause)
+		})
+	}
+}
+
+func TestParseError_Error(t *testing.T) {
+	var sd SessionDescription
+	err := sd.UnmarshalString("v=0\r\no=jdoe")
+	assert.ErrorIs(t, err, io.EOF)
+	assert.EqualError(t, err,
+		"sdp: line 2 column 7: sdp: unexpected end of description: EOF: \"o=jdoe\"")
+}
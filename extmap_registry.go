@@ -0,0 +1,352 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Ranges of the RTP header extension IDs.
+// https://datatracker.ietf.org/doc/html/rfc8285#section-5
+const (
+	extMapMaxOneByteID = 14
+	extMapMaxTwoByteID = 255
+)
+
+var (
+	errExtMapIDsExhausted   = errors.New("sdp: no RTP header extension ID left")
+	errExtMapIDOutOfRange   = errors.New("sdp: extmap ID out of range")
+	errExtMapDuplicateID    = errors.New("sdp: extmap ID is used more than once")
+	errExtMapDuplicateURI   = errors.New("sdp: extmap URI is mapped more than once")
+	errExtMapBundleConflict = errors.New("sdp: extmap differs between bundled m-sections")
+)
+
+// ExtMaps parses and returns the extmap attributes of the media description.
+func (d *MediaDescription) ExtMaps() ([]ExtMap, error) {
+	var extMaps []ExtMap
+	for _, a := range d.Attributes {
+		if !isExtMapAttribute(a) {
+			continue
+		}
+
+		var extMap ExtMap
+		if err := extMap.Unmarshal(a.String()); err != nil {
+			return nil, err
+		}
+		extMaps = append(extMaps, extMap)
+	}
+
+	return extMaps, nil
+}
+
+// isExtMapAttribute reports whether a is an extmap attribute, either in the
+// parsed form or in the property form written by WithExtMap.
+func isExtMapAttribute(a Attribute) bool {
+	return a.Key == AttrKeyExtMap || strings.HasPrefix(a.Key, AttrKeyExtMap+":")
+}
+
+// AllowsMixedExtMaps reports whether the media description may use the
+// one-byte and two-byte header extension forms, and with them the IDs
+// 15-255, set by an extmap-allow-mixed attribute of the media description
+// or of the session. session may be nil.
+// https://datatracker.ietf.org/doc/html/rfc8285#section-6
+func (d *MediaDescription) AllowsMixedExtMaps(session *SessionDescription) bool {
+	if _, ok := d.Attribute(AttrKeyExtMapAllowMixed); ok {
+		return true
+	}
+	if session != nil {
+		_, ok := session.Attribute(AttrKeyExtMapAllowMixed)
+
+		return ok
+	}
+
+	return false
+}
+
+// ExtMapRegistry allocates the IDs of RTP header extensions. An extension
+// keeps its ID in every m-section, as required for the m-sections of a
+// BUNDLE group.
+// https://datatracker.ietf.org/doc/html/rfc8843#section-9.2
+type ExtMapRegistry struct {
+	// AllowMixed allows the two-byte IDs 15-255 once the one-byte IDs 1-14
+	// are exhausted. It requires an extmap-allow-mixed attribute.
+	AllowMixed bool
+
+	uris map[int]string
+	ids  map[string]int
+
+	// reserved holds the IDs of extmap attributes that could not be parsed.
+	reserved map[int]bool
+}
+
+// NewExtMapRegistry creates a registry with the header extensions of the
+// session. AllowMixed is set when the session has an extmap-allow-mixed
+// attribute. When an ID or URI is mapped differently in several m-sections,
+// the first mapping is kept. The IDs of extmap attributes that cannot be
+// parsed otherwise are not allocated. s may be nil.
+func NewExtMapRegistry(s *SessionDescription) *ExtMapRegistry {
+	registry := &ExtMapRegistry{}
+	if s == nil {
+		return registry
+	}
+
+	_, registry.AllowMixed = s.Attribute(AttrKeyExtMapAllowMixed)
+	for _, md := range s.MediaDescriptions {
+		registry.addMediaDescription(md)
+	}
+
+	return registry
+}
+
+func (r *ExtMapRegistry) addMediaDescription(d *MediaDescription) {
+	for _, a := range d.Attributes {
+		if !isExtMapAttribute(a) {
+			continue
+		}
+
+		var extMap ExtMap
+		if err := extMap.Unmarshal(a.String()); err == nil && extMap.URI != nil {
+			r.add(extMap.Value, extMap.URI.String())
+		} else if id, ok := extMapID(a); ok {
+			if r.reserved == nil {
+				r.reserved = map[int]bool{}
+			}
+			r.reserved[id] = true
+		}
+	}
+}
+
+// extMapID returns the ID of an extmap attribute whose direction or URI may
+// be invalid.
+func extMapID(a Attribute) (int, bool) {
+	_, value, _ := strings.Cut(a.String(), ":")
+	fields := strings.Fields(value)
+	if len(fields) == 0 {
+		return 0, false
+	}
+
+	id, _, _ := strings.Cut(fields[0], "/")
+	parsed, err := strconv.Atoi(id)
+	if err != nil {
+		return 0, false
+	}
+
+	return parsed, true
+}
+
+func (r *ExtMapRegistry) used(id int) bool {
+	_, used := r.uris[id]
+
+	return used || r.reserved[id]
+}
+
+func (r *ExtMapRegistry) add(id int, uri string) {
+	if r.uris == nil {
+		r.uris, r.ids = map[int]string{}, map[string]int{}
+	}
+
+	_, idUsed := r.uris[id]
+	_, uriUsed := r.ids[uri]
+	if !idUsed && !uriUsed {
+		r.uris[id], r.ids[uri] = uri, id
+	}
+}
+
+// ID returns the ID of the header extension with the given URI.
+func (r *ExtMapRegistry) ID(uri string) (int, bool) {
+	id, ok := r.ids[uri]
+
+	return id, ok
+}
+
+// URI returns the URI of the header extension with the given ID.
+func (r *ExtMapRegistry) URI(id int) (string, bool) {
+	uri, ok := r.uris[id]
+
+	return uri, ok
+}
+
+// Register returns the ID of the header extension with the given URI,
+// allocating one when the URI is not registered yet. The default IDs, such
+// as DefExtMapValueTransportCC, are used when free, otherwise the lowest
+// free one-byte ID, then the lowest free two-byte ID if AllowMixed is set.
+func (r *ExtMapRegistry) Register(uri string) (int, error) {
+	if id, ok := r.ids[uri]; ok {
+		return id, nil
+	}
+
+	if id, ok := defaultExtMapID(uri); ok {
+		if !r.used(id) {
+			r.add(id, uri)
+
+			return id, nil
+		}
+	}
+
+	maxID := extMapMaxOneByteID
+	if r.AllowMixed {
+		maxID = extMapMaxTwoByteID
+	}
+	for id := 1; id <= maxID; id++ {
+		if !r.used(id) {
+			r.add(id, uri)
+
+			return id, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: %v", errExtMapIDsExhausted, uri)
+}
+
+// AddExtMap registers the header extension and adds its extmap attribute to
+// the media description, unless the media description has it already.
+func (r *ExtMapRegistry) AddExtMap(d *MediaDescription, uri string) (int, error) {
+	id, err := r.Register(uri)
+	if err != nil {
+		return 0, err
+	}
+
+	extMaps, err := d.ExtMaps()
+	if err != nil {
+		return 0, err
+	}
+	for _, extMap := range extMaps {
+		if extMap.Value == id {
+			return id, nil
+		}
+	}
+
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return 0, err
+	}
+	d.WithExtMap(ExtMap{Value: id, URI: parsed})
+
+	return id, nil
+}
+
+func defaultExtMapID(uri string) (int, bool) {
+	switch uri {
+	case ABSSendTimeURI:
+		return DefExtMapValueABSSendTime, true
+	case TransportCCURI:
+		return DefExtMapValueTransportCC, true
+	case SDESMidURI:
+		return DefExtMapValueSDESMid, true
+	case SDESRTPStreamIDURI:
+		return DefExtMapValueSDESRTPStreamID, true
+	default:
+		return 0, false
+	}
+}
+
+// NegotiateExtMaps returns the header extensions of an answer to the offered
+// ones: the offered extensions with a supported URI keep their ID, and their
+// direction is reversed. Two-byte IDs are dropped unless allowMixed is set.
+// https://datatracker.ietf.org/doc/html/rfc8285#section-7
+func NegotiateExtMaps(offered []ExtMap, supported []string, allowMixed bool) []ExtMap {
+	var answered []ExtMap
+	for _, extMap := range offered {
+		if extMap.URI == nil || (extMap.Value > extMapMaxOneByteID && !allowMixed) {
+			continue
+		}
+
+		for _, uri := range supported {
+			if extMap.URI.String() == uri {
+				answered = append(answered, ExtMap{Value: extMap.Value, Direction: extMap.Direction.Reverse(), URI: extMap.URI})
+
+				break
+			}
+		}
+	}
+
+	return answered
+}
+
+// ValidateExtMaps checks the header extensions of every m-section: IDs are
+// in 1-14, or in 1-255 with extmap-allow-mixed, and no ID or URI is mapped
+// twice. Within a BUNDLE group, an extension has the same ID in every
+// m-section.
+// https://datatracker.ietf.org/doc/html/rfc8285#section-5
+// https://datatracker.ietf.org/doc/html/rfc8843#section-9.2
+func (s *SessionDescription) ValidateExtMaps() error {
+	for _, md := range s.MediaDescriptions {
+		if err := validateExtMaps(md, md.AllowsMixedExtMaps(s)); err != nil {
+			return err
+		}
+	}
+
+	groups, err := s.GroupsBySemantics(SemanticTokenBundle)
+	if err != nil {
+		return err
+	}
+
+	for _, group := range groups {
+		if err := validateBundleExtMaps(s, group); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateBundleExtMaps checks that the header extensions of the m-sections
+// of a BUNDLE group are mapped to the same IDs.
+func validateBundleExtMaps(s *SessionDescription, group Group) error {
+	registry := &ExtMapRegistry{}
+	for _, mid := range group.MIDs {
+		md, ok := s.MediaDescriptionByMID(mid)
+		if !ok || md.IsRejected() {
+			continue
+		}
+
+		extMaps, err := md.ExtMaps()
+		if err != nil {
+			return err
+		}
+		for _, extMap := range extMaps {
+			uri := extMap.URI.String()
+			registeredURI, idUsed := registry.URI(extMap.Value)
+			registeredID, uriUsed := registry.ID(uri)
+			if (idUsed && registeredURI != uri) || (uriUsed && registeredID != extMap.Value) {
+				return fmt.Errorf("%w: mid %v: %d %v", errExtMapBundleConflict, mid, extMap.Value, uri)
+			}
+			registry.add(extMap.Value, uri)
+		}
+	}
+
+	return nil
+}
+
+func validateExtMaps(d *MediaDescription, allowMixed bool) error {
+	extMaps, err := d.ExtMaps()
+	if err != nil {
+		return err
+	}
+
+	maxID := extMapMaxOneByteID
+	if allowMixed {
+		maxID = extMapMaxTwoByteID
+	}
+
+	ids, uris := map[int]bool{}, map[string]bool{}
+	for _, extMap := range extMaps {
+		uri := extMap.URI.String()
+		switch {
+		case extMap.Value < 1 || extMap.Value > maxID:
+			return fmt.Errorf("%w: %d", errExtMapIDOutOfRange, extMap.Value)
+		case ids[extMap.Value]:
+			return fmt.Errorf("%w: %d", errExtMapDuplicateID, extMap.Value)
+		case uris[uri]:
+			return fmt.Errorf("%w: %v", errExtMapDuplicateURI, uri)
+		}
+		ids[extMap.Value], uris[uri] = true, true
+	}
+
+	return nil
+}
@@ -0,0 +1,272 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const extMapSDP = "v=0\r\n" +
+	"o=- 4215775240449105457 2 IN IP4 127.0.0.1\r\n" +
+	"s=-\r\n" +
+	"t=0 0\r\n" +
+	"a=group:BUNDLE 0 1\r\n" +
+	"m=audio 9 UDP/TLS/RTP/SAVPF 111\r\n" +
+	"a=mid:0\r\n" +
+	"a=extmap:1 urn:ietf:params:rtp-hdrext:ssrc-audio-level\r\n" +
+	"a=extmap:3/sendonly urn:ietf:params:rtp-hdrext:sdes:mid\r\n" +
+	"a=rtpmap:111 opus/48000/2\r\n" +
+	"m=video 9 UDP/TLS/RTP/SAVPF 96\r\n" +
+	"a=mid:1\r\n" +
+	"a=extmap:3 urn:ietf:params:rtp-hdrext:sdes:mid\r\n" +
+	"a=extmap:5 http://www.webrtc.org/experiments/rtp-hdrext/abs-send-time\r\n" +
+	"a=rtpmap:96 VP8/90000\r\n"
+
+func TestMediaDescription_ExtMaps(t *testing.T) {
+	var s SessionDescription
+	assert.NoError(t, s.UnmarshalString(extMapSDP))
+
+	extMaps, err := s.MediaDescriptions[0].ExtMaps()
+	assert.NoError(t, err)
+	if assert.Len(t, extMaps, 2) {
+		assert.Equal(t, 1, extMaps[0].Value)
+		assert.Equal(t, AudioLevelURI, extMaps[0].URI.String())
+		assert.Equal(t, 3, extMaps[1].Value)
+		assert.Equal(t, DirectionSendOnly, extMaps[1].Direction)
+	}
+
+	md := NewJSEPMediaDescription("video", nil).WithTransportCCExtMap()
+	extMaps, err = md.ExtMaps()
+	assert.NoError(t, err)
+	if assert.Len(t, extMaps, 1) {
+		assert.Equal(t, DefExtMapValueTransportCC, extMaps[0].Value)
+	}
+
+	md.WithValueAttribute(AttrKeyExtMap, "x "+SDESMidURI)
+	_, err = md.ExtMaps()
+	assert.ErrorIs(t, err, errSyntaxError)
+}
+
+func TestExtMapRegistry(t *testing.T) {
+	var s SessionDescription
+	assert.NoError(t, s.UnmarshalString(extMapSDP))
+
+	registry := NewExtMapRegistry(&s)
+	assert.False(t, registry.AllowMixed)
+
+	id, ok := registry.ID(SDESMidURI)
+	assert.True(t, ok)
+	assert.Equal(t, 3, id)
+	uri, ok := registry.URI(5)
+	assert.True(t, ok)
+	assert.Equal(t, ABSSendTimeURI, uri)
+	_, ok = registry.URI(2)
+	assert.False(t, ok)
+
+	// Registered URIs keep their ID.
+	id, err := registry.Register(ABSSendTimeURI)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, id)
+
+	// Known URIs get their default ID when it is free.
+	id, err = registry.Register(TransportCCURI)
+	assert.NoError(t, err)
+	assert.Equal(t, DefExtMapValueTransportCC, id)
+
+	// Otherwise the lowest free ID is used.
+	id, err = registry.Register(SDESRTPStreamIDURI)
+	assert.NoError(t, err)
+	assert.Equal(t, DefExtMapValueSDESRTPStreamID, id)
+	id, err = registry.Register(SDESRepairRTPStreamIDURI)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, id)
+}
+
+func TestExtMapRegistry_Exhausted(t *testing.T) {
+	registry := NewExtMapRegistry(nil)
+	for i := 1; i <= 14; i++ {
+		id, err := registry.Register("urn:example:" + strconv.Itoa(i))
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, id, 14)
+	}
+
+	_, err := registry.Register("urn:example:15")
+	assert.ErrorIs(t, err, errExtMapIDsExhausted)
+
+	registry.AllowMixed = true
+	id, err := registry.Register("urn:example:15")
+	assert.NoError(t, err)
+	assert.Equal(t, 15, id)
+
+	for i := 16; i <= 255; i++ {
+		_, err = registry.Register("urn:example:" + strconv.Itoa(i))
+		assert.NoError(t, err)
+	}
+	_, err = registry.Register("urn:example:256")
+	assert.ErrorIs(t, err, errExtMapIDsExhausted)
+}
+
+func TestExtMapRegistry_AllowMixed(t *testing.T) {
+	s := &SessionDescription{Attributes: []Attribute{NewPropertyAttribute(AttrKeyExtMapAllowMixed)}}
+	assert.True(t, NewExtMapRegistry(s).AllowMixed)
+
+	md := NewJSEPMediaDescription("audio", nil)
+	assert.False(t, md.AllowsMixedExtMaps(nil))
+	assert.True(t, md.AllowsMixedExtMaps(s))
+	md.WithPropertyAttribute(AttrKeyExtMapAllowMixed)
+	assert.True(t, md.AllowsMixedExtMaps(nil))
+}
+
+func TestExtMapRegistry_AddExtMap(t *testing.T) {
+	var s SessionDescription
+	assert.NoError(t, s.UnmarshalString(extMapSDP))
+
+	registry := NewExtMapRegistry(&s)
+	audio := s.MediaDescriptions[0]
+
+	// The ID of abs-send-time in the bundled video section is reused.
+	id, err := registry.AddExtMap(audio, ABSSendTimeURI)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, id)
+	extMaps, err := audio.ExtMaps()
+	assert.NoError(t, err)
+	assert.Len(t, extMaps, 3)
+
+	_, err = registry.AddExtMap(audio, ABSSendTimeURI)
+	assert.NoError(t, err)
+	extMaps, err = audio.ExtMaps()
+	assert.NoError(t, err)
+	assert.Len(t, extMaps, 3)
+	assert.NoError(t, s.ValidateExtMaps())
+}
+
+func TestExtMapRegistry_Unparsable(t *testing.T) {
+	var s SessionDescription
+	s.WithMedia((&MediaDescription{}).
+		WithValueAttribute(AttrKeyExtMap, "1 "+SDESMidURI).
+		WithValueAttribute(AttrKeyExtMap, "2/bogus "+AudioLevelURI).
+		WithValueAttribute(AttrKeyExtMap, "3"))
+
+	// The IDs of the extmaps that cannot be parsed are not allocated.
+	registry := NewExtMapRegistry(&s)
+	id, ok := registry.ID(SDESMidURI)
+	assert.True(t, ok)
+	assert.Equal(t, 1, id)
+	id, err := registry.Register(TransportCCURI)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, id)
+	_, ok = registry.URI(2)
+	assert.False(t, ok)
+}
+
+func TestNegotiateExtMaps(t *testing.T) {
+	parse := func(raw string) *url.URL {
+		uri, err := url.Parse(raw)
+		assert.NoError(t, err)
+
+		return uri
+	}
+	offered := []ExtMap{
+		{Value: 1, URI: parse(AudioLevelURI)},
+		{Value: 3, Direction: DirectionSendOnly, URI: parse(SDESMidURI)},
+		{Value: 7, URI: parse(TransportCCURI)},
+		{Value: 16, URI: parse(ABSSendTimeURI)},
+	}
+	supported := []string{SDESMidURI, TransportCCURI, ABSSendTimeURI}
+
+	assert.Equal(t, []ExtMap{
+		{Value: 3, Direction: DirectionRecvOnly, URI: parse(SDESMidURI)},
+		{Value: 7, URI: parse(TransportCCURI)},
+	}, NegotiateExtMaps(offered, supported, false))
+
+	answered := NegotiateExtMaps(offered, supported, true)
+	if assert.Len(t, answered, 3) {
+		assert.Equal(t, 16, answered[2].Value)
+	}
+
+	assert.Empty(t, NegotiateExtMaps(offered, nil, true))
+}
+
+func TestSessionDescription_ValidateExtMaps(t *testing.T) {
+	for _, test := range []struct {
+		Name  string
+		Lines string
+		Err   error
+	}{
+		{
+			Name: "valid",
+		},
+		{
+			Name:  "two-byte ID without extmap-allow-mixed",
+			Lines: "a=extmap:15 urn:example:a\r\n",
+			Err:   errExtMapIDOutOfRange,
+		},
+		{
+			Name:  "two-byte ID with extmap-allow-mixed",
+			Lines: "a=extmap-allow-mixed\r\na=extmap:15 urn:example:a\r\n",
+		},
+		{
+			Name:  "duplicate ID",
+			Lines: "a=extmap:5 urn:example:a\r\n",
+			Err:   errExtMapDuplicateID,
+		},
+		{
+			Name:  "duplicate URI",
+			Lines: "a=extmap:6 http://www.webrtc.org/experiments/rtp-hdrext/abs-send-time\r\n",
+			Err:   errExtMapDuplicateURI,
+		},
+		{
+			Name:  "ID differs within BUNDLE",
+			Lines: "a=extmap:6 urn:ietf:params:rtp-hdrext:ssrc-audio-level\r\n",
+			Err:   errExtMapBundleConflict,
+		},
+		{
+			Name:  "URI differs within BUNDLE",
+			Lines: "a=extmap:1 urn:example:a\r\n",
+			Err:   errExtMapBundleConflict,
+		},
+	} {
+		t.Run(test.Name, func(t *testing.T) {
+			var s SessionDescription
+			assert.NoError(t, s.UnmarshalString(extMapSDP+test.Lines))
+			assert.ErrorIs(t, s.ValidateExtMaps(), test.Err)
+		})
+	}
+}
+
+func TestSessionDescription_ValidateExtMaps_Unbundled(t *testing.T) {
+	var s SessionDescription
+	assert.NoError(t, s.UnmarshalString(extMapSDP+"a=extmap:6 urn:ietf:params:rtp-hdrext:ssrc-audio-level\r\n"))
+	s.Attributes = nil
+	assert.NoError(t, s.ValidateExtMaps())
+}
+
+func TestNewAnswer_ExtMaps(t *testing.T) {
+	var offer SessionDescription
+	assert.NoError(t, offer.UnmarshalString(extMapSDP))
+	offer.WithPropertyAttribute(AttrKeyExtMapAllowMixed)
+	offer.MediaDescriptions[1].WithValueAttribute(AttrKeyExtMap, "20 "+TransportCCURI)
+
+	answer, err := NewAnswer(&offer, AnswerOptions{Media: map[string]MediaCapabilities{
+		"audio": {
+			Codecs:           []Codec{{Name: "opus", ClockRate: 48000, EncodingParameters: "2"}},
+			HeaderExtensions: []string{SDESMidURI},
+		},
+		"video": {
+			Codecs:           []Codec{{Name: "VP8", ClockRate: 90000}},
+			HeaderExtensions: []string{SDESMidURI, TransportCCURI},
+		},
+	}})
+	assert.NoError(t, err)
+
+	_, ok := answer.Attribute(AttrKeyExtMapAllowMixed)
+	assert.True(t, ok)
+	assert.Contains(t, answer.MediaDescriptions[0].Attributes, NewAttribute(AttrKeyExtMap, "3/recvonly "+SDESMidURI))
+	assert.Contains(t, answer.MediaDescriptions[1].Attributes, NewAttribute(AttrKeyExtMap, "20 "+TransportCCURI))
+	assert.NoError(t, answer.ValidateExtMaps())
+}
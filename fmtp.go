@@ -0,0 +1,616 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var errInvalidFmtp = errors.New("sdp: invalid fmtp")
+
+// Fmtp is the parsed value of an "a=fmtp" attribute, without the payload
+// type. The codec-specific types returned by ParseFmtp implement the
+// comparison rules of their payload format.
+type Fmtp interface {
+	// Parameter returns the value of the parameter with the given name.
+	// Parameter names are case-insensitive.
+	Parameter(key string) (string, bool)
+
+	// Match reports whether both describe configurations of the same codec
+	// that can interoperate. Receiver preferences, such as the H.264 level,
+	// are ignored.
+	Match(other Fmtp) bool
+}
+
+// ParseFmtp parses the parameters of an fmtp attribute for the codec with
+// the given encoding name. The result is one of H264Fmtp, H265Fmtp, VP9Fmtp,
+// AV1Fmtp, OpusFmtp, RTXFmtp, REDFmtp and TelephoneEventFmtp, or
+// FmtpParameters for the other codecs.
+func ParseFmtp(codecName, fmtp string) (Fmtp, error) {
+	var parsed interface {
+		Fmtp
+		Unmarshal(fmtp string) error
+	}
+	switch strings.ToLower(codecName) {
+	case "h264":
+		parsed = &H264Fmtp{}
+	case "h265":
+		parsed = &H265Fmtp{}
+	case "vp9":
+		parsed = &VP9Fmtp{}
+	case "av1":
+		parsed = &AV1Fmtp{}
+	case "opus":
+		parsed = &OpusFmtp{}
+	case "rtx":
+		parsed = &RTXFmtp{}
+	case "red":
+		parsed = &REDFmtp{}
+	case "telephone-event":
+		parsed = &TelephoneEventFmtp{}
+	default:
+		return parseFmtpParameters(fmtp), nil
+	}
+
+	if err := parsed.Unmarshal(fmtp); err != nil {
+		return nil, err
+	}
+
+	return parsed, nil
+}
+
+// fmtpMatch compares the fmtp of two codecs with the rules of their payload
+// format, or textually when either cannot be parsed.
+func fmtpMatch(codecName, a, b string) bool {
+	parsedA, errA := ParseFmtp(codecName, a)
+	parsedB, errB := ParseFmtp(codecName, b)
+	if errA != nil || errB != nil {
+		return equivalentFmtp(a, b)
+	}
+
+	return parsedA.Match(parsedB)
+}
+
+// FmtpParameters are the "name=value" parameters of an fmtp attribute,
+// keyed by their lower-case name. Parameters without a value are kept with
+// an empty value. Two FmtpParameters match when they hold the same
+// parameters.
+type FmtpParameters map[string]string
+
+func parseFmtpParameters(fmtp string) FmtpParameters {
+	parameters := FmtpParameters{}
+	for parameter := range strings.SplitSeq(fmtp, ";") {
+		key, value, _ := strings.Cut(strings.TrimSpace(parameter), "=")
+		if key = strings.ToLower(strings.TrimSpace(key)); key != "" {
+			parameters[key] = strings.TrimSpace(value)
+		}
+	}
+
+	return parameters
+}
+
+// Parameter returns the value of the parameter with the given name.
+func (p FmtpParameters) Parameter(key string) (string, bool) {
+	value, ok := p[strings.ToLower(key)]
+
+	return value, ok
+}
+
+// Match reports whether other holds the same parameters.
+func (p FmtpParameters) Match(other Fmtp) bool {
+	o, ok := other.(FmtpParameters)
+
+	return ok && maps.Equal(p, o)
+}
+
+// uint parses an unsigned parameter in the range [minValue, maxValue],
+// or returns defaultValue when the parameter is absent.
+func (p FmtpParameters) uint(key string, defaultValue, minValue, maxValue uint64) (uint64, error) {
+	value, ok := p[key]
+	if !ok {
+		return defaultValue, nil
+	}
+
+	parsed, err := strconv.ParseUint(value, 10, 64)
+	if err != nil || parsed < minValue || parsed > maxValue {
+		return 0, fmt.Errorf("%w: %v=%v", errInvalidFmtp, key, value)
+	}
+
+	return parsed, nil
+}
+
+func (p FmtpParameters) bool(key string) (bool, error) {
+	value, err := p.uint(key, 0, 0, 1)
+
+	return value == 1, err
+}
+
+// H264Fmtp holds the fmtp parameters of H.264.
+// https://datatracker.ietf.org/doc/html/rfc6184#section-8.1
+type H264Fmtp struct {
+	// Parameters holds all the parameters of the fmtp.
+	Parameters FmtpParameters
+
+	// ProfileIDC, ProfileIOP and LevelIDC are the bytes of the
+	// profile-level-id, which is 42000a, the Baseline profile at level 1,
+	// when absent.
+	ProfileIDC uint8
+	ProfileIOP uint8
+	LevelIDC   uint8
+
+	// PacketizationMode is 0, single NAL unit mode, when absent.
+	PacketizationMode uint8
+
+	LevelAsymmetryAllowed bool
+}
+
+// Unmarshal parses the fmtp of H.264.
+func (f *H264Fmtp) Unmarshal(fmtp string) error {
+	parameters := parseFmtpParameters(fmtp)
+	*f = H264Fmtp{Parameters: parameters, LevelIDC: 10}
+
+	if value, ok := parameters["profile-level-id"]; ok {
+		profileLevelID, err := hex.DecodeString(value)
+		if err != nil || len(profileLevelID) != 3 {
+			return fmt.Errorf("%w: profile-level-id=%v", errInvalidFmtp, value)
+		}
+		f.ProfileIDC, f.ProfileIOP, f.LevelIDC = profileLevelID[0], profileLevelID[1], profileLevelID[2]
+	} else {
+		f.ProfileIDC = 0x42
+	}
+
+	packetizationMode, err := parameters.uint("packetization-mode", 0, 0, 2)
+	if err != nil {
+		return err
+	}
+	f.PacketizationMode = uint8(packetizationMode)
+
+	f.LevelAsymmetryAllowed, err = parameters.bool("level-asymmetry-allowed")
+
+	return err
+}
+
+// Parameter returns the value of the parameter with the given name.
+func (f *H264Fmtp) Parameter(key string) (string, bool) {
+	return f.Parameters.Parameter(key)
+}
+
+// Match reports whether other has the same profile and packetization mode.
+// The profile is derived from profile_idc and the constraint flags of
+// profile-iop, so that equivalent encodings such as 42e01f and 42c01f, both
+// Constrained Baseline, match. Unknown profiles match only when both bytes
+// are equal. The level is ignored.
+// https://datatracker.ietf.org/doc/html/rfc6184#section-8.2.2
+func (f *H264Fmtp) Match(other Fmtp) bool {
+	o, ok := other.(*H264Fmtp)
+	if !ok || f.PacketizationMode != o.PacketizationMode {
+		return false
+	}
+
+	profile, known := h264ProfileOf(f.ProfileIDC, f.ProfileIOP)
+	otherProfile, otherKnown := h264ProfileOf(o.ProfileIDC, o.ProfileIOP)
+	if !known || !otherKnown {
+		return f.ProfileIDC == o.ProfileIDC && f.ProfileIOP == o.ProfileIOP
+	}
+
+	return profile == otherProfile
+}
+
+type h264Profile int
+
+const (
+	h264ProfileConstrainedBaseline h264Profile = iota + 1
+	h264ProfileBaseline
+	h264ProfileMain
+	h264ProfileConstrainedHigh
+	h264ProfileHigh
+	h264ProfilePredictiveHigh444
+)
+
+// h264ProfilePatterns maps profile_idc and the bits of profile-iop that are
+// set in mask to a profile, as libwebrtc does. The constraint_set flags are
+// the high bits of profile-iop and the reserved low bits must be zero.
+// https://datatracker.ietf.org/doc/html/rfc6184#section-8.1
+var h264ProfilePatterns = []struct { //nolint:gochecknoglobals
+	profileIDC  uint8
+	mask, value uint8
+	profile     h264Profile
+}{
+	{0x42, 0b01001111, 0b01000000, h264ProfileConstrainedBaseline},
+	{0x4D, 0b10001111, 0b10000000, h264ProfileConstrainedBaseline},
+	{0x58, 0b11001111, 0b11000000, h264ProfileConstrainedBaseline},
+	{0x42, 0b01001111, 0b00000000, h264ProfileBaseline},
+	{0x58, 0b11001111, 0b10000000, h264ProfileBaseline},
+	{0x4D, 0b10101111, 0b00000000, h264ProfileMain},
+	{0x64, 0b11111111, 0b00000000, h264ProfileHigh},
+	{0x64, 0b11111111, 0b00001100, h264ProfileConstrainedHigh},
+	{0xF4, 0b11111111, 0b00000000, h264ProfilePredictiveHigh444},
+}
+
+func h264ProfileOf(profileIDC, profileIOP uint8) (h264Profile, bool) {
+	for _, pattern := range h264ProfilePatterns {
+		if pattern.profileIDC == profileIDC && profileIOP&pattern.mask == pattern.value {
+			return pattern.profile, true
+		}
+	}
+
+	return 0, false
+}
+
+// H265Fmtp holds the fmtp parameters of H.265.
+// https://datatracker.ietf.org/doc/html/rfc7798#section-7.1
+type H265Fmtp struct {
+	// Parameters holds all the parameters of the fmtp.
+	Parameters FmtpParameters
+
+	// ProfileSpace, ProfileID, TierFlag and LevelID default to 0, 1 (Main),
+	// 0 and 93 (level 3.1).
+	ProfileSpace uint8
+	ProfileID    uint8
+	TierFlag     uint8
+	LevelID      uint8
+
+	// TxMode is "SRST", single RTP stream on a single media transport, when
+	// absent.
+	TxMode string
+}
+
+// Unmarshal parses the fmtp of H.265.
+func (f *H265Fmtp) Unmarshal(fmtp string) error {
+	parameters := parseFmtpParameters(fmtp)
+	*f = H265Fmtp{Parameters: parameters, TxMode: "SRST"}
+
+	for _, field := range []struct {
+		key                              string
+		value                            *uint8
+		defaultValue, minValue, maxValue uint64
+	}{
+		{"profile-space", &f.ProfileSpace, 0, 0, 3},
+		{"profile-id", &f.ProfileID, 1, 0, 31},
+		{"tier-flag", &f.TierFlag, 0, 0, 1},
+		{"level-id", &f.LevelID, 93, 0, 255},
+	} {
+		value, err := parameters.uint(field.key, field.defaultValue, field.minValue, field.maxValue)
+		if err != nil {
+			return err
+		}
+		*field.value = uint8(value)
+	}
+
+	if value, ok := parameters["tx-mode"]; ok {
+		f.TxMode = value
+	}
+
+	return nil
+}
+
+// Parameter returns the value of the parameter with the given name.
+func (f *H265Fmtp) Parameter(key string) (string, bool) {
+	return f.Parameters.Parameter(key)
+}
+
+// Match reports whether other has the same profile, tier and transmission
+// mode. The level is ignored.
+// https://datatracker.ietf.org/doc/html/rfc7798#section-7.2.2
+func (f *H265Fmtp) Match(other Fmtp) bool {
+	o, ok := other.(*H265Fmtp)
+
+	return ok && f.ProfileSpace == o.ProfileSpace && f.ProfileID == o.ProfileID &&
+		f.TierFlag == o.TierFlag && f.TxMode == o.TxMode
+}
+
+// VP9Fmtp holds the fmtp parameters of VP9.
+// https://datatracker.ietf.org/doc/html/rfc9628#section-6
+type VP9Fmtp struct {
+	// Parameters holds all the parameters of the fmtp.
+	Parameters FmtpParameters
+
+	// ProfileID is 0 when absent.
+	ProfileID uint8
+}
+
+// Unmarshal parses the fmtp of VP9.
+func (f *VP9Fmtp) Unmarshal(fmtp string) error {
+	parameters := parseFmtpParameters(fmtp)
+	profileID, err := parameters.uint("profile-id", 0, 0, 3)
+	*f = VP9Fmtp{Parameters: parameters, ProfileID: uint8(profileID)}
+
+	return err
+}
+
+// Parameter returns the value of the parameter with the given name.
+func (f *VP9Fmtp) Parameter(key string) (string, bool) {
+	return f.Parameters.Parameter(key)
+}
+
+// Match reports whether other has the same profile.
+func (f *VP9Fmtp) Match(other Fmtp) bool {
+	o, ok := other.(*VP9Fmtp)
+
+	return ok && f.ProfileID == o.ProfileID
+}
+
+// AV1Fmtp holds the fmtp parameters of AV1.
+// https://aomediacodec.github.io/av1-rtp-spec/#72-sdp-parameters
+type AV1Fmtp struct {
+	// Parameters holds all the parameters of the fmtp.
+	Parameters FmtpParameters
+
+	// Profile, LevelIdx and Tier default to 0 (Main), 5 (level 3.1) and 0.
+	Profile  uint8
+	LevelIdx uint8
+	Tier     uint8
+}
+
+// Unmarshal parses the fmtp of AV1.
+func (f *AV1Fmtp) Unmarshal(fmtp string) error {
+	parameters := parseFmtpParameters(fmtp)
+	*f = AV1Fmtp{Parameters: parameters}
+
+	for _, field := range []struct {
+		key                    string
+		value                  *uint8
+		defaultValue, maxValue uint64
+	}{
+		{"profile", &f.Profile, 0, 2},
+		{"level-idx", &f.LevelIdx, 5, 31},
+		{"tier", &f.Tier, 0, 1},
+	} {
+		value, err := parameters.uint(field.key, field.defaultValue, 0, field.maxValue)
+		if err != nil {
+			return err
+		}
+		*field.value = uint8(value)
+	}
+
+	return nil
+}
+
+// Parameter returns the value of the parameter with the given name.
+func (f *AV1Fmtp) Parameter(key string) (string, bool) {
+	return f.Parameters.Parameter(key)
+}
+
+// Match reports whether other has the same profile. The level and tier are
+// ignored.
+func (f *AV1Fmtp) Match(other Fmtp) bool {
+	o, ok := other.(*AV1Fmtp)
+
+	return ok && f.Profile == o.Profile
+}
+
+// OpusFmtp holds the fmtp parameters of Opus. Unset durations and bitrates
+// are 0.
+// https://datatracker.ietf.org/doc/html/rfc7587#section-6.1
+type OpusFmtp struct {
+	// Parameters holds all the parameters of the fmtp.
+	Parameters FmtpParameters
+
+	// MaxPlaybackRate and SpropMaxCaptureRate are 48000 when absent.
+	MaxPlaybackRate     uint32
+	SpropMaxCaptureRate uint32
+
+	MaxPTime          uint32
+	PTime             uint32
+	MinPTime          uint32
+	MaxAverageBitrate uint32
+
+	Stereo       bool
+	SpropStereo  bool
+	CBR          bool
+	UseInbandFEC bool
+	UseDTX       bool
+}
+
+// Unmarshal parses the fmtp of Opus.
+func (f *OpusFmtp) Unmarshal(fmtp string) error {
+	parameters := parseFmtpParameters(fmtp)
+	*f = OpusFmtp{Parameters: parameters}
+
+	for _, field := range []struct {
+		key                              string
+		value                            *uint32
+		defaultValue, minValue, maxValue uint64
+	}{
+		{"maxplaybackrate", &f.MaxPlaybackRate, 48000, 8000, 48000},
+		{"sprop-maxcapturerate", &f.SpropMaxCaptureRate, 48000, 8000, 48000},
+		{"maxptime", &f.MaxPTime, 0, 3, 120},
+		{"ptime", &f.PTime, 0, 3, 120},
+		{"minptime", &f.MinPTime, 0, 3, 120},
+		{"maxaveragebitrate", &f.MaxAverageBitrate, 0, 6000, 510000},
+	} {
+		value, err := parameters.uint(field.key, field.defaultValue, field.minValue, field.maxValue)
+		if err != nil {
+			return err
+		}
+		*field.value = uint32(value)
+	}
+
+	for _, field := range []struct {
+		key   string
+		value *bool
+	}{
+		{"stereo", &f.Stereo},
+		{"sprop-stereo", &f.SpropStereo},
+		{"cbr", &f.CBR},
+		{"useinbandfec", &f.UseInbandFEC},
+		{"usedtx", &f.UseDTX},
+	} {
+		var err error
+		if *field.value, err = parameters.bool(field.key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Parameter returns the value of the parameter with the given name.
+func (f *OpusFmtp) Parameter(key string) (string, bool) {
+	return f.Parameters.Parameter(key)
+}
+
+// Match reports whether other is an Opus fmtp. The Opus parameters state the
+// preferences of the receiver and never prevent interoperation.
+// https://datatracker.ietf.org/doc/html/rfc7587#section-7
+func (f *OpusFmtp) Match(other Fmtp) bool {
+	_, ok := other.(*OpusFmtp)
+
+	return ok
+}
+
+// RTXFmtp holds the fmtp parameters of the retransmission payload format.
+// https://datatracker.ietf.org/doc/html/rfc4588#section-8.1
+type RTXFmtp struct {
+	// Parameters holds all the parameters of the fmtp.
+	Parameters FmtpParameters
+
+	// APT is the associated payload type, the payload type repaired.
+	APT uint8
+
+	// RTXTime is the time in milliseconds that packets are kept for
+	// retransmission, 0 when absent.
+	RTXTime uint32
+}
+
+// Unmarshal parses the fmtp of RTX. The apt parameter is required.
+func (f *RTXFmtp) Unmarshal(fmtp string) error {
+	parameters := parseFmtpParameters(fmtp)
+	if _, ok := parameters["apt"]; !ok {
+		return fmt.Errorf("%w: missing apt", errInvalidFmtp)
+	}
+
+	apt, err := parameters.uint("apt", 0, 0, 127)
+	if err != nil {
+		return err
+	}
+	rtxTime, err := parameters.uint("rtx-time", 0, 0, 1<<32-1)
+	if err != nil {
+		return err
+	}
+	*f = RTXFmtp{Parameters: parameters, APT: uint8(apt), RTXTime: uint32(rtxTime)}
+
+	return nil
+}
+
+// Parameter returns the value of the parameter with the given name.
+func (f *RTXFmtp) Parameter(key string) (string, bool) {
+	return f.Parameters.Parameter(key)
+}
+
+// Match reports whether other repairs the same payload type.
+func (f *RTXFmtp) Match(other Fmtp) bool {
+	o, ok := other.(*RTXFmtp)
+
+	return ok && f.APT == o.APT
+}
+
+// REDFmtp holds the fmtp of the redundant audio data payload format, the
+// payload types of the primary and redundant encodings.
+//
+//	a=fmtp:100 111/111
+//
+// https://datatracker.ietf.org/doc/html/rfc2198#section-5
+type REDFmtp struct {
+	PayloadTypes []uint8
+}
+
+// Unmarshal parses the fmtp of RED. An empty fmtp has no payload types.
+func (f *REDFmtp) Unmarshal(fmtp string) error {
+	*f = REDFmtp{}
+	if fmtp = strings.TrimSpace(fmtp); fmtp == "" {
+		return nil
+	}
+
+	for field := range strings.SplitSeq(fmtp, "/") {
+		payloadType, err := strconv.ParseUint(field, 10, 7)
+		if err != nil {
+			return fmt.Errorf("%w: %v", errInvalidFmtp, fmtp)
+		}
+		f.PayloadTypes = append(f.PayloadTypes, uint8(payloadType))
+	}
+
+	return nil
+}
+
+// Parameter returns false, the RED fmtp has no named parameters.
+func (f *REDFmtp) Parameter(string) (string, bool) {
+	return "", false
+}
+
+// Match reports whether other has the same redundancy list.
+func (f *REDFmtp) Match(other Fmtp) bool {
+	o, ok := other.(*REDFmtp)
+
+	return ok && slices.Equal(f.PayloadTypes, o.PayloadTypes)
+}
+
+// TelephoneEventRange is a range of DTMF and telephony events.
+type TelephoneEventRange struct {
+	First, Last uint8
+}
+
+// TelephoneEventFmtp holds the fmtp of telephone-event, the events the
+// receiver supports.
+//
+//	a=fmtp:101 0-15,66,70
+//
+// https://datatracker.ietf.org/doc/html/rfc4733#section-2.5.1.3
+type TelephoneEventFmtp struct {
+	Events []TelephoneEventRange
+}
+
+// Unmarshal parses the fmtp of telephone-event. When empty, the events 0-15
+// are supported.
+func (f *TelephoneEventFmtp) Unmarshal(fmtp string) error {
+	*f = TelephoneEventFmtp{}
+	if fmtp = strings.TrimSpace(fmtp); fmtp == "" {
+		f.Events = []TelephoneEventRange{{First: 0, Last: 15}}
+
+		return nil
+	}
+
+	for field := range strings.SplitSeq(fmtp, ",") {
+		first, last, isRange := strings.Cut(strings.TrimSpace(field), "-")
+		if !isRange {
+			last = first
+		}
+
+		firstEvent, errFirst := strconv.ParseUint(first, 10, 8)
+		lastEvent, errLast := strconv.ParseUint(last, 10, 8)
+		if errFirst != nil || errLast != nil || firstEvent > lastEvent {
+			return fmt.Errorf("%w: %v", errInvalidFmtp, fmtp)
+		}
+		f.Events = append(f.Events, TelephoneEventRange{First: uint8(firstEvent), Last: uint8(lastEvent)})
+	}
+
+	return nil
+}
+
+// Supports reports whether the event is part of one of the ranges.
+func (f *TelephoneEventFmtp) Supports(event uint8) bool {
+	return slices.ContainsFunc(f.Events, func(r TelephoneEventRange) bool {
+		return r.First <= event && event <= r.Last
+	})
+}
+
+// Parameter returns false, the telephone-event fmtp has no named
+// parameters.
+func (f *TelephoneEventFmtp) Parameter(string) (string, bool) {
+	return "", false
+}
+
+// Match reports whether other is a telephone-event fmtp. The events are the
+// capabilities of the receiver and never prevent interoperation.
+func (f *TelephoneEventFmtp) Match(other Fmtp) bool {
+	_, ok := other.(*TelephoneEventFmtp)
+
+	return ok
+}
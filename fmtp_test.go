@@ -0,0 +1,182 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFmtp(t *testing.T) {
+	fmtp, err := ParseFmtp("H264", "profile-level-id=42e01f;level-asymmetry-allowed=1;packetization-mode=1")
+	assert.NoError(t, err)
+	h264, ok := fmtp.(*H264Fmtp)
+	assert.True(t, ok)
+	assert.Equal(t, uint8(0x42), h264.ProfileIDC)
+	assert.Equal(t, uint8(0xe0), h264.ProfileIOP)
+	assert.Equal(t, uint8(0x1f), h264.LevelIDC)
+	assert.Equal(t, uint8(1), h264.PacketizationMode)
+	assert.True(t, h264.LevelAsymmetryAllowed)
+	value, ok := fmtp.Parameter("Profile-Level-Id")
+	assert.True(t, ok)
+	assert.Equal(t, "42e01f", value)
+
+	fmtp, err = ParseFmtp("h264", "")
+	assert.NoError(t, err)
+	assert.Equal(t, &H264Fmtp{Parameters: FmtpParameters{}, ProfileIDC: 0x42, LevelIDC: 10}, fmtp)
+
+	fmtp, err = ParseFmtp("H265", "level-id=120;tx-mode=MRST")
+	assert.NoError(t, err)
+	assert.Equal(t, &H265Fmtp{
+		Parameters: FmtpParameters{"level-id": "120", "tx-mode": "MRST"},
+		ProfileID:  1, LevelID: 120, TxMode: "MRST",
+	}, fmtp)
+
+	fmtp, err = ParseFmtp("VP9", "profile-id=2")
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(2), fmtp.(*VP9Fmtp).ProfileID) //nolint:forcetypeassert
+
+	fmtp, err = ParseFmtp("AV1", "profile=1")
+	assert.NoError(t, err)
+	assert.Equal(t, &AV1Fmtp{Parameters: FmtpParameters{"profile": "1"}, Profile: 1, LevelIdx: 5}, fmtp)
+
+	fmtp, err = ParseFmtp("opus", "minptime=10;useinbandfec=1;maxaveragebitrate=64000")
+	assert.NoError(t, err)
+	opus, ok := fmtp.(*OpusFmtp)
+	assert.True(t, ok)
+	assert.Equal(t, uint32(10), opus.MinPTime)
+	assert.Equal(t, uint32(64000), opus.MaxAverageBitrate)
+	assert.Equal(t, uint32(48000), opus.MaxPlaybackRate)
+	assert.True(t, opus.UseInbandFEC)
+	assert.False(t, opus.Stereo)
+
+	fmtp, err = ParseFmtp("rtx", "apt=96;rtx-time=3000")
+	assert.NoError(t, err)
+	assert.Equal(t, &RTXFmtp{Parameters: FmtpParameters{"apt": "96", "rtx-time": "3000"}, APT: 96, RTXTime: 3000}, fmtp)
+
+	fmtp, err = ParseFmtp("red", "111/111")
+	assert.NoError(t, err)
+	assert.Equal(t, &REDFmtp{PayloadTypes: []uint8{111, 111}}, fmtp)
+	_, ok = fmtp.Parameter("111/111")
+	assert.False(t, ok)
+
+	fmtp, err = ParseFmtp("telephone-event", "0-15,66,70")
+	assert.NoError(t, err)
+	events, ok := fmtp.(*TelephoneEventFmtp)
+	assert.True(t, ok)
+	assert.Equal(t, []TelephoneEventRange{{0, 15}, {66, 66}, {70, 70}}, events.Events)
+	assert.True(t, events.Supports(11))
+	assert.True(t, events.Supports(66))
+	assert.False(t, events.Supports(67))
+
+	fmtp, err = ParseFmtp("VP8", "max-fs=12288; max-fr=60")
+	assert.NoError(t, err)
+	assert.Equal(t, FmtpParameters{"max-fs": "12288", "max-fr": "60"}, fmtp)
+}
+
+func TestParseFmtp_Errors(t *testing.T) {
+	for _, test := range []struct {
+		name, fmtp string
+	}{
+		{"H264", "profile-level-id=42e0"},
+		{"H264", "profile-level-id=zz001f"},
+		{"H264", "packetization-mode=3"},
+		{"H264", "level-asymmetry-allowed=yes"},
+		{"H265", "profile-id=32"},
+		{"VP9", "profile-id=4"},
+		{"AV1", "tier=2"},
+		{"opus", "maxplaybackrate=96000"},
+		{"opus", "stereo=2"},
+		{"rtx", "rtx-time=3000"},
+		{"rtx", "apt=128"},
+		{"red", "111/x"},
+		{"telephone-event", "15-0"},
+		{"telephone-event", "0-256"},
+	} {
+		_, err := ParseFmtp(test.name, test.fmtp)
+		assert.ErrorIs(t, err, errInvalidFmtp, test.fmtp)
+	}
+}
+
+func TestFmtp_Match(t *testing.T) {
+	for _, test := range []struct {
+		name, a, b string
+		match      bool
+	}{
+		{"H264", "profile-level-id=42e01f;packetization-mode=1", "packetization-mode=1;profile-level-id=42e034", true},
+		{"H264", "profile-level-id=42e01f;packetization-mode=1", "profile-level-id=42e01f", false},
+		{"H264", "profile-level-id=42e01f;packetization-mode=1", "profile-level-id=640c1f;packetization-mode=1", false},
+		{"H264", "", "profile-level-id=42001f", true},
+		{"H264", "profile-level-id=42e01f;packetization-mode=1", "profile-level-id=42c01f;packetization-mode=1", true},
+		{"H264", "profile-level-id=42e01f;packetization-mode=1", "profile-level-id=4de01f;packetization-mode=1", true},
+		{"H264", "profile-level-id=42e01f;packetization-mode=1", "profile-level-id=58c01f;packetization-mode=1", true},
+		{"H264", "profile-level-id=42e01f;packetization-mode=1", "profile-level-id=42001f;packetization-mode=1", false},
+		{"H264", "profile-level-id=42e01f;packetization-mode=1", "profile-level-id=4d001f;packetization-mode=1", false},
+		{"H264", "profile-level-id=42e01f;packetization-mode=1", "profile-level-id=42c01f", false},
+		{"H264", "profile-level-id=42e01f", "profile-level-id=42c01f;packetization-mode=2", false},
+		{"H264", "profile-level-id=4d0032", "profile-level-id=4d1034", true},
+		{"H264", "profile-level-id=640c1f", "profile-level-id=64001f", false},
+		{"H264", "profile-level-id=010203", "profile-level-id=010203", true},
+		{"H264", "profile-level-id=010203", "profile-level-id=010303", false},
+		{"H265", "level-id=93", "level-id=120", true},
+		{"H265", "profile-id=1", "profile-id=2", false},
+		{"VP9", "", "profile-id=0", true},
+		{"VP9", "profile-id=0", "profile-id=2", false},
+		{"AV1", "level-idx=5", "level-idx=8", true},
+		{"AV1", "profile=0", "profile=1", false},
+		{"opus", "minptime=10;useinbandfec=1", "stereo=1", true},
+		{"rtx", "apt=96", "apt=96;rtx-time=3000", true},
+		{"rtx", "apt=96", "apt=97", false},
+		{"red", "111/111", "111/111", true},
+		{"red", "111/111", "111/111/111", false},
+		{"telephone-event", "0-15", "0-16", true},
+		{"VP8", "max-fs=12288;max-fr=60", "max-fr=60;max-fs=12288", true},
+		{"VP8", "max-fs=12288;max-fr=60", "max-fs=12288", false},
+	} {
+		a, err := ParseFmtp(test.name, test.a)
+		assert.NoError(t, err)
+		b, err := ParseFmtp(test.name, test.b)
+		assert.NoError(t, err)
+		assert.Equal(t, test.match, a.Match(b), "%s %s %s", test.name, test.a, test.b)
+		assert.Equal(t, test.match, fmtpMatch(test.name, test.a, test.b))
+	}
+
+	h264, err := ParseFmtp("H264", "")
+	assert.NoError(t, err)
+	vp9, err := ParseFmtp("VP9", "")
+	assert.NoError(t, err)
+	assert.False(t, h264.Match(vp9))
+
+	// Parameters that cannot be parsed are compared textually.
+	assert.True(t, fmtpMatch("H264", "profile-level-id=x;packetization-mode=1", "packetization-mode=1;profile-level-id=x"))
+	assert.False(t, fmtpMatch("H264", "profile-level-id=x", "profile-level-id=42e01f"))
+}
+
+func TestGetPayloadTypeForCodec_FmtpRules(t *testing.T) {
+	sd := getTestSessionDescription()
+
+	// The level of H.264 is ignored.
+	payloadType, err := sd.GetPayloadTypeForCodec(Codec{
+		Name: "H264",
+		Fmtp: "profile-level-id=42e034;packetization-mode=1",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(126), payloadType)
+
+	// So are the equivalent encodings of Constrained Baseline.
+	payloadType, err = sd.GetPayloadTypeForCodec(Codec{
+		Name: "H264",
+		Fmtp: "profile-level-id=42c01f;packetization-mode=1",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(126), payloadType)
+
+	payloadType, err = sd.GetPayloadTypeForCodec(Codec{Name: "H264", Fmtp: "profile-level-id=42e00a"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(97), payloadType)
+
+	_, err = sd.GetPayloadTypeForCodec(Codec{Name: "H264", Fmtp: "profile-level-id=640c1f;packetization-mode=1"})
+	assert.ErrorIs(t, err, errCodecNotFound)
+}
@@ -0,0 +1,255 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// SDPFragmentContentType is the media type of an SDP fragment body, used for
+// example by the PATCH requests of WHIP and WHEP.
+// https://datatracker.ietf.org/doc/html/rfc8840#section-9.1
+const SDPFragmentContentType = "application/trickle-ice-sdpfrag"
+
+var (
+	errInvalidFragment    = errors.New("sdp: invalid SDP fragment")
+	errFragmentMissingMID = errors.New("sdp: SDP fragment m-line has no mid")
+	errFragmentUnknownMID = errors.New("sdp: SDP fragment mid not found in session")
+)
+
+// SDPFragment is the body of an "application/trickle-ice-sdpfrag", which
+// carries ICE candidates and credentials for the m-sections of a session
+// without the rest of the session description.
+//
+//	sdp-fragment = *(session-level a= lines) *(m= line *(a= lines))
+//
+// The m= lines identify the m-sections of the session by their a=mid
+// attribute. Their port, proto and formats carry no meaning.
+// https://datatracker.ietf.org/doc/html/rfc8840#section-4.4
+// https://datatracker.ietf.org/doc/html/rfc8840#section-9
+type SDPFragment struct {
+	// Attributes are the session-level attributes, such as ice-options and
+	// session-level ICE credentials.
+	Attributes []Attribute
+
+	// MediaDescriptions hold the m= line and the attributes of each m-section
+	// of the fragment.
+	MediaDescriptions []*MediaDescription
+}
+
+// NewSDPFragment creates a fragment with the ICE credentials, candidates and
+// end-of-candidates of the m-sections of s with the given mids, or of all
+// m-sections that are not rejected when no mid is given. The ice-options and
+// BUNDLE groups of the session are included.
+func NewSDPFragment(s *SessionDescription, mids ...string) (*SDPFragment, error) {
+	fragment := &SDPFragment{}
+	for _, a := range s.Attributes {
+		switch {
+		case a.Key == AttrKeyICEOptions, a.Key == attrKeyICEUfrag, a.Key == attrKeyICEPwd,
+			a.Key == AttrKeyGroup && strings.HasPrefix(a.Value, SemanticTokenBundle+" "):
+			fragment.Attributes = append(fragment.Attributes, a)
+		}
+	}
+
+	for _, mid := range mids {
+		if _, ok := s.MediaDescriptionByMID(mid); !ok {
+			return nil, fmt.Errorf("%w: %v", errFragmentUnknownMID, mid)
+		}
+	}
+
+	for _, md := range s.MediaDescriptions {
+		mid, ok := md.Attribute(AttrKeyMID)
+		if !ok || (len(mids) == 0 && md.IsRejected()) || (len(mids) > 0 && !slices.Contains(mids, mid)) {
+			continue
+		}
+
+		fragmentMedia := &MediaDescription{MediaName: MediaName{
+			Media:   md.MediaName.Media,
+			Port:    RangedPort{Value: 9},
+			Protos:  slices.Clone(md.MediaName.Protos),
+			Formats: slices.Clone(md.MediaName.Formats),
+		}}
+		fragmentMedia.WithValueAttribute(AttrKeyMID, mid)
+		for _, a := range md.Attributes {
+			switch a.Key {
+			case attrKeyICEUfrag, attrKeyICEPwd, AttrKeyCandidate, AttrKeyEndOfCandidates:
+				fragmentMedia.Attributes = append(fragmentMedia.Attributes, a)
+			}
+		}
+		fragment.MediaDescriptions = append(fragment.MediaDescriptions, fragmentMedia)
+	}
+
+	return fragment, nil
+}
+
+// UnmarshalString parses an SDP fragment. Only a= and m= lines are allowed,
+// empty lines are ignored.
+func (f *SDPFragment) UnmarshalString(value string) error {
+	*f = SDPFragment{}
+	for line := range strings.Lines(value) {
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "a="):
+			attribute := unmarshalAttribute(line[2:])
+			if len(f.MediaDescriptions) == 0 {
+				f.Attributes = append(f.Attributes, attribute)
+			} else {
+				md := f.MediaDescriptions[len(f.MediaDescriptions)-1]
+				md.Attributes = append(md.Attributes, attribute)
+			}
+		case strings.HasPrefix(line, "m="):
+			md := &MediaDescription{}
+			if err := md.MediaName.UnmarshalText([]byte(line[2:])); err != nil {
+				return fmt.Errorf("%w: %w", errInvalidFragment, err)
+			}
+			f.MediaDescriptions = append(f.MediaDescriptions, md)
+		default:
+			return fmt.Errorf("%w: %v", errInvalidFragment, line)
+		}
+	}
+
+	return nil
+}
+
+// Unmarshal converts the value into a string and then calls UnmarshalString.
+func (f *SDPFragment) Unmarshal(value []byte) error {
+	return f.UnmarshalString(string(value))
+}
+
+// Marshal returns the fragment as the body of an SDP fragment.
+func (f *SDPFragment) Marshal() ([]byte, error) {
+	marsh := marshaller(nil)
+	for _, a := range f.Attributes {
+		marsh.addKeyValue("a=", a.marshalInto)
+	}
+	for _, md := range f.MediaDescriptions {
+		marsh.addKeyValue("m=", md.MediaName.marshalInto)
+		for _, a := range md.Attributes {
+			marsh.addKeyValue("a=", a.marshalInto)
+		}
+	}
+
+	return marsh, nil
+}
+
+// Match returns the m-section of s that each m-section of the fragment
+// refers to, matched by mid.
+func (f *SDPFragment) Match(s *SessionDescription) ([]*MediaDescription, error) {
+	matches := make([]*MediaDescription, 0, len(f.MediaDescriptions))
+	for _, md := range f.MediaDescriptions {
+		mid, ok := md.Attribute(AttrKeyMID)
+		if !ok {
+			return nil, errFragmentMissingMID
+		}
+
+		match, ok := s.MediaDescriptionByMID(mid)
+		if !ok {
+			return nil, fmt.Errorf("%w: %v", errFragmentUnknownMID, mid)
+		}
+		matches = append(matches, match)
+	}
+
+	return matches, nil
+}
+
+// ICECredentials returns the ICE credentials of an m-section of the
+// fragment, falling back to the session-level credentials of the fragment.
+func (f *SDPFragment) ICECredentials(md *MediaDescription) (ufrag, pwd string) {
+	return iceCredentials(&SessionDescription{Attributes: f.Attributes}, md)
+}
+
+// EndOfCandidates reports whether the fragment signals the end of candidates
+// for an m-section of the fragment, either in the m-section or at session
+// level.
+// https://datatracker.ietf.org/doc/html/rfc8838#section-8.2
+func (f *SDPFragment) EndOfCandidates(md *MediaDescription) bool {
+	return hasAttribute(md.Attributes, AttrKeyEndOfCandidates) ||
+		hasAttribute(f.Attributes, AttrKeyEndOfCandidates)
+}
+
+// ICERestart reports whether the fragment restarts ICE for any of the
+// m-sections of s it refers to, which is the case when it carries ICE
+// credentials that differ from the current ones.
+// https://datatracker.ietf.org/doc/html/rfc8840#section-4.4
+func (f *SDPFragment) ICERestart(s *SessionDescription) (bool, error) {
+	matches, err := f.Match(s)
+	if err != nil {
+		return false, err
+	}
+
+	for i, md := range f.MediaDescriptions {
+		if f.restarts(s, md, matches[i]) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (f *SDPFragment) restarts(s *SessionDescription, md, match *MediaDescription) bool {
+	ufrag, pwd := f.ICECredentials(md)
+	currentUfrag, currentPwd := iceCredentials(s, match)
+
+	return ufrag != "" && currentUfrag != "" && (ufrag != currentUfrag || pwd != currentPwd)
+}
+
+// ApplyFragment adds the candidates and end-of-candidates of the fragment to
+// the m-sections of the session they refer to. Candidates that are already
+// present are not added twice. When the fragment restarts ICE for an
+// m-section, its credentials replace the current ones and its previous
+// candidates and end-of-candidates are removed first. A session-level
+// end-of-candidates applies to every m-section that is not rejected.
+func (s *SessionDescription) ApplyFragment(f *SDPFragment) error {
+	matches, err := f.Match(s)
+	if err != nil {
+		return err
+	}
+
+	for i, md := range f.MediaDescriptions {
+		match := matches[i]
+		if f.restarts(s, md, match) {
+			ufrag, pwd := f.ICECredentials(md)
+			match.Attributes = slices.DeleteFunc(match.Attributes, func(a Attribute) bool {
+				return anyOf(a.Key, attrKeyICEUfrag, attrKeyICEPwd, AttrKeyCandidate, AttrKeyEndOfCandidates)
+			})
+			match.WithICECredentials(ufrag, pwd)
+		}
+
+		for _, a := range md.Attributes {
+			if a.Key == AttrKeyCandidate && !slices.Contains(match.Attributes, a) {
+				match.Attributes = append(match.Attributes, a)
+			}
+		}
+		if hasAttribute(md.Attributes, AttrKeyEndOfCandidates) {
+			match.withEndOfCandidates()
+		}
+	}
+
+	if hasAttribute(f.Attributes, AttrKeyEndOfCandidates) {
+		for _, md := range s.MediaDescriptions {
+			if !md.IsRejected() {
+				md.withEndOfCandidates()
+			}
+		}
+	}
+
+	return nil
+}
+
+// withEndOfCandidates moves the end-of-candidates attribute after the last
+// candidate.
+func (d *MediaDescription) withEndOfCandidates() {
+	d.Attributes = slices.DeleteFunc(d.Attributes, func(a Attribute) bool {
+		return a.Key == AttrKeyEndOfCandidates
+	})
+	d.WithPropertyAttribute(AttrKeyEndOfCandidates)
+}
+
+func hasAttribute(attributes []Attribute, key string) bool {
+	return slices.ContainsFunc(attributes, func(a Attribute) bool { return a.Key == key })
+}
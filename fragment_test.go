@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const fragmentSDP = "a=ice-options:trickle ice2\r\n" +
+	"a=group:BUNDLE 0 1\r\n" +
+	"m=audio 9 UDP/TLS/RTP/SAVPF 111\r\n" +
+	"a=mid:0\r\n" +
+	"a=ice-ufrag:abcd\r\n" +
+	"a=ice-pwd:0123456789012345678901\r\n" +
+	"a=candidate:2 1 udp 1694498815 198.51.100.1 5000 typ srflx raddr 192.0.2.1 rport 5000\r\n" +
+	"a=end-of-candidates\r\n"
+
+func TestSDPFragment_Unmarshal(t *testing.T) {
+	var fragment SDPFragment
+	assert.NoError(t, fragment.UnmarshalString(fragmentSDP))
+	assert.Equal(t, []Attribute{
+		NewAttribute(AttrKeyICEOptions, "trickle ice2"),
+		NewAttribute(AttrKeyGroup, "BUNDLE 0 1"),
+	}, fragment.Attributes)
+	assert.Len(t, fragment.MediaDescriptions, 1)
+	assert.Equal(t, "audio", fragment.MediaDescriptions[0].MediaName.Media)
+	assert.Len(t, fragment.MediaDescriptions[0].Attributes, 5)
+
+	ufrag, pwd := fragment.ICECredentials(fragment.MediaDescriptions[0])
+	assert.Equal(t, "abcd", ufrag)
+	assert.Equal(t, "0123456789012345678901", pwd)
+	assert.True(t, fragment.EndOfCandidates(fragment.MediaDescriptions[0]))
+
+	raw, err := fragment.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, fragmentSDP, string(raw))
+
+	assert.NoError(t, fragment.Unmarshal([]byte("a=ice-ufrag:efgh\na=ice-pwd:9876543210987654321098\n\n")))
+	assert.Empty(t, fragment.MediaDescriptions)
+	ufrag, _ = fragment.ICECredentials(&MediaDescription{})
+	assert.Equal(t, "efgh", ufrag)
+
+	assert.ErrorIs(t, fragment.UnmarshalString("v=0\r\n"), errInvalidFragment)
+	assert.ErrorIs(t, fragment.UnmarshalString("m=audio\r\n"), errInvalidFragment)
+}
+
+func TestSDPFragment_Apply(t *testing.T) {
+	var session SessionDescription
+	assert.NoError(t, session.UnmarshalString(diffOfferSDP))
+	var fragment SDPFragment
+	assert.NoError(t, fragment.UnmarshalString(fragmentSDP))
+
+	matches, err := fragment.Match(&session)
+	assert.NoError(t, err)
+	assert.Equal(t, []*MediaDescription{session.MediaDescriptions[0]}, matches)
+
+	restart, err := fragment.ICERestart(&session)
+	assert.NoError(t, err)
+	assert.False(t, restart)
+
+	// Applying the same fragment twice adds its candidate once.
+	assert.NoError(t, session.ApplyFragment(&fragment))
+	assert.NoError(t, session.ApplyFragment(&fragment))
+	candidates, err := session.MediaDescriptions[0].ICECandidates()
+	assert.NoError(t, err)
+	assert.Len(t, candidates, 2)
+	last := session.MediaDescriptions[0].Attributes[len(session.MediaDescriptions[0].Attributes)-1]
+	assert.Equal(t, NewPropertyAttribute(AttrKeyEndOfCandidates), last)
+	_, ok := session.MediaDescriptions[1].Attribute(AttrKeyEndOfCandidates)
+	assert.False(t, ok)
+
+	restartFragment := &SDPFragment{
+		Attributes: []Attribute{NewPropertyAttribute(AttrKeyEndOfCandidates)},
+		MediaDescriptions: []*MediaDescription{(&MediaDescription{MediaName: MediaName{
+			Media: "video", Port: RangedPort{Value: 9}, Protos: []string{"UDP", "TLS", "RTP", "SAVPF"}, Formats: []string{"96"},
+		}}).
+			WithValueAttribute(AttrKeyMID, "0").
+			WithICECredentials("efgh", "9876543210987654321098").
+			WithCandidate("1 1 udp 2130706431 192.0.2.2 6000 typ host")},
+	}
+	restart, err = restartFragment.ICERestart(&session)
+	assert.NoError(t, err)
+	assert.True(t, restart)
+	assert.True(t, restartFragment.EndOfCandidates(restartFragment.MediaDescriptions[0]))
+
+	assert.NoError(t, session.ApplyFragment(restartFragment))
+	audio := session.MediaDescriptions[0]
+	ufrag, pwd := iceCredentials(&session, audio)
+	assert.Equal(t, "efgh", ufrag)
+	assert.Equal(t, "9876543210987654321098", pwd)
+	candidates, err = audio.ICECandidates()
+	assert.NoError(t, err)
+	assert.Len(t, candidates, 1)
+	assert.Equal(t, "192.0.2.2", candidates[0].Address)
+	_, ok = session.MediaDescriptions[1].Attribute(AttrKeyEndOfCandidates)
+	assert.True(t, ok)
+
+	assert.ErrorIs(t, session.ApplyFragment(&SDPFragment{MediaDescriptions: []*MediaDescription{{}}}),
+		errFragmentMissingMID)
+	_, err = (&SDPFragment{MediaDescriptions: []*MediaDescription{
+		(&MediaDescription{}).WithValueAttribute(AttrKeyMID, "5"),
+	}}).ICERestart(&session)
+	assert.ErrorIs(t, err, errFragmentUnknownMID)
+}
+
+func TestNewSDPFragment(t *testing.T) {
+	var session SessionDescription
+	assert.NoError(t, session.UnmarshalString(diffOfferSDP))
+	session.WithICETrickleAdvertised()
+
+	fragment, err := NewSDPFragment(&session, "0")
+	assert.NoError(t, err)
+	raw, err := fragment.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, "a=group:BUNDLE 0 1\r\n"+
+		"a=ice-options:trickle\r\n"+
+		"m=audio 9 UDP/TLS/RTP/SAVPF 111 0\r\n"+
+		"a=mid:0\r\n"+
+		"a=ice-ufrag:abcd\r\n"+
+		"a=ice-pwd:0123456789012345678901\r\n"+
+		"a=candidate:1 1 udp 2130706431 192.0.2.1 5000 typ host\r\n", string(raw))
+
+	session.MediaDescriptions[1].MediaName.Port.Value = 0
+	fragment, err = NewSDPFragment(&session)
+	assert.NoError(t, err)
+	assert.Len(t, fragment.MediaDescriptions, 1)
+
+	_, err = NewSDPFragment(&session, "2")
+	assert.ErrorIs(t, err, errFragmentUnknownMID)
+}
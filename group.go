@@ -0,0 +1,244 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var (
+	errInvalidGroup        = errors.New("sdp: invalid group")
+	errGroupUnknownMID     = errors.New("sdp: group references unknown mid")
+	errGroupDuplicateMID   = errors.New("sdp: mid is part of more than one BUNDLE group")
+	errGroupNotBundle      = errors.New("sdp: group semantics is not BUNDLE")
+	errBundleTagNotFound   = errors.New("sdp: BUNDLE-tag m-section not found")
+	errBundleTagRejected   = errors.New("sdp: BUNDLE-tag m-section is rejected")
+	errBundleTagBundleOnly = errors.New("sdp: BUNDLE-tag m-section is bundle-only")
+)
+
+// Group represents an "a=group" attribute, which groups m-sections by their
+// identification tags.
+//
+//	group-attribute = "a=group:" semantics *(SP identification-tag)
+//
+// https://datatracker.ietf.org/doc/html/rfc5888#section-5
+type Group struct {
+	Semantics string
+	MIDs      []string
+}
+
+// Unmarshal creates a Group from a string. The "a=" and "group:" prefixes
+// are optional.
+func (g *Group) Unmarshal(raw string) error {
+	raw = strings.TrimPrefix(strings.TrimSpace(raw), attributeKey)
+	raw = strings.TrimPrefix(raw, AttrKeyGroup+":")
+
+	fields := strings.Fields(raw)
+	if len(fields) == 0 {
+		return fmt.Errorf("%w: %v", errInvalidGroup, raw)
+	}
+
+	g.Semantics = fields[0]
+	g.MIDs = fields[1:]
+
+	return nil
+}
+
+// Marshal creates a string from a Group.
+func (g Group) Marshal() string {
+	return AttrKeyGroup + ":" + g.String()
+}
+
+// String returns the value of the "a=group" attribute.
+func (g Group) String() string {
+	return stringFromMarshal(g.marshalInto, g.marshalSize)
+}
+
+func (g Group) marshalInto(b []byte) []byte {
+	b = append(b, g.Semantics...)
+	for _, mid := range g.MIDs {
+		b = append(append(b, ' '), mid...)
+	}
+
+	return b
+}
+
+func (g Group) marshalSize() (size int) {
+	size = len(g.Semantics)
+	for _, mid := range g.MIDs {
+		size += 1 + len(mid)
+	}
+
+	return size
+}
+
+// Contains reports whether the group contains the given mid.
+func (g Group) Contains(mid string) bool {
+	return slices.Contains(g.MIDs, mid)
+}
+
+// Groups parses and returns the "a=group" attributes of the session.
+func (s *SessionDescription) Groups() ([]Group, error) {
+	var groups []Group
+	for _, a := range s.Attributes {
+		if a.Key != AttrKeyGroup {
+			continue
+		}
+
+		var group Group
+		if err := group.Unmarshal(a.Value); err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+
+	return groups, nil
+}
+
+// GroupsBySemantics returns the groups with the given semantics, such as
+// SemanticTokenBundle or SemanticTokenLipSynchronization.
+func (s *SessionDescription) GroupsBySemantics(semantics string) ([]Group, error) {
+	groups, err := s.Groups()
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(groups, func(g Group) bool {
+		return g.Semantics != semantics
+	}), nil
+}
+
+// WithGroup adds an "a=group" attribute to the session description.
+func (s *SessionDescription) WithGroup(g Group) *SessionDescription {
+	return s.WithValueAttribute(AttrKeyGroup, g.String())
+}
+
+// MediaDescriptionByMID returns the media description with the given mid.
+func (s *SessionDescription) MediaDescriptionByMID(mid string) (*MediaDescription, bool) {
+	for _, m := range s.MediaDescriptions {
+		if value, ok := m.Attribute(AttrKeyMID); ok && value == mid {
+			return m, true
+		}
+	}
+
+	return nil, false
+}
+
+// ValidateGroups checks that every mid referenced by a group exists in the
+// session, and that no mid is part of more than one BUNDLE group.
+// https://datatracker.ietf.org/doc/html/rfc8843#section-7.1
+func (s *SessionDescription) ValidateGroups() error {
+	groups, err := s.Groups()
+	if err != nil {
+		return err
+	}
+
+	bundled := map[string]bool{}
+	for _, group := range groups {
+		for _, mid := range group.MIDs {
+			if _, ok := s.MediaDescriptionByMID(mid); !ok {
+				return fmt.Errorf("%w: %v %v", errGroupUnknownMID, group.Semantics, mid)
+			}
+
+			if group.Semantics != SemanticTokenBundle {
+				continue
+			}
+			if bundled[mid] {
+				return fmt.Errorf("%w: %v", errGroupDuplicateMID, mid)
+			}
+			bundled[mid] = true
+		}
+	}
+
+	return nil
+}
+
+// IsBundleOnly reports whether the media description carries the
+// "a=bundle-only" attribute.
+// https://datatracker.ietf.org/doc/html/rfc8843#section-6
+func (d *MediaDescription) IsBundleOnly() bool {
+	_, ok := d.Attribute(AttrKeyBundleOnly)
+
+	return ok
+}
+
+// WithBundleOnly marks the media description as bundle-only, which sets the
+// port to zero and adds the "a=bundle-only" attribute.
+// https://datatracker.ietf.org/doc/html/rfc8843#section-7.2.1
+func (d *MediaDescription) WithBundleOnly() *MediaDescription {
+	d.MediaName.Port = RangedPort{Value: 0}
+	if d.IsBundleOnly() {
+		return d
+	}
+
+	return d.WithPropertyAttribute(AttrKeyBundleOnly)
+}
+
+// IsRejected reports whether the media description is rejected or disabled,
+// which is the case for a zero port unless it is bundle-only.
+// https://datatracker.ietf.org/doc/html/rfc3264#section-6
+func (d *MediaDescription) IsRejected() bool {
+	return d.MediaName.Port.Value == 0 && !d.IsBundleOnly()
+}
+
+// BundleTaggedMediaDescription returns the tagged m-section of a BUNDLE
+// group, which is the m-section identified by the first mid of the group. The
+// tagged m-section carries the transport of the whole group, so it must be
+// neither rejected nor bundle-only.
+// https://datatracker.ietf.org/doc/html/rfc8843#section-7.2
+func (s *SessionDescription) BundleTaggedMediaDescription(group Group) (*MediaDescription, error) {
+	if group.Semantics != SemanticTokenBundle {
+		return nil, fmt.Errorf("%w: %v", errGroupNotBundle, group.Semantics)
+	}
+	if len(group.MIDs) == 0 {
+		return nil, errBundleTagNotFound
+	}
+
+	tagged, ok := s.MediaDescriptionByMID(group.MIDs[0])
+	switch {
+	case !ok:
+		return nil, fmt.Errorf("%w: %v", errBundleTagNotFound, group.MIDs[0])
+	case tagged.IsBundleOnly():
+		return nil, fmt.Errorf("%w: %v", errBundleTagBundleOnly, group.MIDs[0])
+	case tagged.IsRejected():
+		return nil, fmt.Errorf("%w: %v", errBundleTagRejected, group.MIDs[0])
+	}
+
+	return tagged, nil
+}
+
+// MovedOutOfBundle returns the mids of the m-sections that the offer placed
+// in a BUNDLE group and that the answer accepted outside of any BUNDLE group.
+// Rejected m-sections are not reported.
+// https://datatracker.ietf.org/doc/html/rfc8843#section-7.3.2
+func MovedOutOfBundle(offer, answer *SessionDescription) ([]string, error) {
+	offered, err := offer.GroupsBySemantics(SemanticTokenBundle)
+	if err != nil {
+		return nil, err
+	}
+
+	answered, err := answer.GroupsBySemantics(SemanticTokenBundle)
+	if err != nil {
+		return nil, err
+	}
+
+	var moved []string
+	for _, group := range offered {
+		for _, mid := range group.MIDs {
+			media, ok := answer.MediaDescriptionByMID(mid)
+			if !ok || media.IsRejected() {
+				continue
+			}
+
+			if !slices.ContainsFunc(answered, func(g Group) bool { return g.Contains(mid) }) {
+				moved = append(moved, mid)
+			}
+		}
+	}
+
+	return moved, nil
+}
@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getBundleTestSessionDescription() *SessionDescription {
+	sd := &SessionDescription{}
+	sd.WithGroup(Group{Semantics: SemanticTokenBundle, MIDs: []string{"0", "1", "2"}}).
+		WithGroup(Group{Semantics: SemanticTokenLipSynchronization, MIDs: []string{"0", "1"}}).
+		WithMedia((&MediaDescription{MediaName: MediaName{Media: "audio", Port: RangedPort{Value: 9}}}).
+			WithValueAttribute(AttrKeyMID, "0")).
+		WithMedia((&MediaDescription{MediaName: MediaName{Media: "video"}}).
+			WithValueAttribute(AttrKeyMID, "1").
+			WithBundleOnly()).
+		WithMedia((&MediaDescription{MediaName: MediaName{Media: "application", Port: RangedPort{Value: 9}}}).
+			WithValueAttribute(AttrKeyMID, "2"))
+
+	return sd
+}
+
+func TestGroup_Unmarshal(t *testing.T) {
+	for _, raw := range []string{
+		"a=group:BUNDLE 0 1 2",
+		"group:BUNDLE 0 1 2",
+		"BUNDLE 0 1 2",
+	} {
+		var group Group
+		assert.NoError(t, group.Unmarshal(raw))
+		assert.Equal(t, Group{Semantics: SemanticTokenBundle, MIDs: []string{"0", "1", "2"}}, group)
+		assert.Equal(t, "group:BUNDLE 0 1 2", group.Marshal())
+		assert.Len(t, group.String(), group.marshalSize())
+	}
+
+	var group Group
+	assert.ErrorIs(t, group.Unmarshal("group:"), errInvalidGroup)
+}
+
+func TestSessionDescription_Groups(t *testing.T) {
+	sd := getBundleTestSessionDescription()
+
+	groups, err := sd.Groups()
+	assert.NoError(t, err)
+	assert.Len(t, groups, 2)
+
+	bundles, err := sd.GroupsBySemantics(SemanticTokenBundle)
+	assert.NoError(t, err)
+	assert.Equal(t, []Group{{Semantics: SemanticTokenBundle, MIDs: []string{"0", "1", "2"}}}, bundles)
+
+	assert.NoError(t, sd.ValidateGroups())
+
+	sd.WithGroup(Group{Semantics: SemanticTokenFlowIdentification, MIDs: []string{"0", "4"}})
+	assert.ErrorIs(t, sd.ValidateGroups(), errGroupUnknownMID)
+
+	sd = getBundleTestSessionDescription()
+	sd.WithGroup(Group{Semantics: SemanticTokenBundle, MIDs: []string{"2"}})
+	assert.ErrorIs(t, sd.ValidateGroups(), errGroupDuplicateMID)
+
+	sd.Attributes = append(sd.Attributes, Attribute{Key: AttrKeyGroup})
+	_, err = sd.Groups()
+	assert.ErrorIs(t, err, errInvalidGroup)
+	assert.ErrorIs(t, sd.ValidateGroups(), errInvalidGroup)
+}
+
+func TestSessionDescription_BundleTaggedMediaDescription(t *testing.T) {
+	sd := getBundleTestSessionDescription()
+
+	tagged, err := sd.BundleTaggedMediaDescription(Group{Semantics: SemanticTokenBundle, MIDs: []string{"0", "1"}})
+	assert.NoError(t, err)
+	assert.Same(t, sd.MediaDescriptions[0], tagged)
+
+	for _, test := range []struct {
+		group Group
+		err   error
+	}{
+		{Group{Semantics: SemanticTokenLipSynchronization, MIDs: []string{"0"}}, errGroupNotBundle},
+		{Group{Semantics: SemanticTokenBundle}, errBundleTagNotFound},
+		{Group{Semantics: SemanticTokenBundle, MIDs: []string{"9"}}, errBundleTagNotFound},
+		{Group{Semantics: SemanticTokenBundle, MIDs: []string{"1", "0"}}, errBundleTagBundleOnly},
+	} {
+		_, err := sd.BundleTaggedMediaDescription(test.group)
+		assert.ErrorIs(t, err, test.err)
+	}
+
+	sd.MediaDescriptions[2].MediaName.Port.Value = 0
+	_, err = sd.BundleTaggedMediaDescription(Group{Semantics: SemanticTokenBundle, MIDs: []string{"2"}})
+	assert.ErrorIs(t, err, errBundleTagRejected)
+}
+
+func TestMediaDescription_BundleOnly(t *testing.T) {
+	md := &MediaDescription{MediaName: MediaName{Port: RangedPort{Value: 9}}}
+	assert.False(t, md.IsBundleOnly())
+	assert.False(t, md.IsRejected())
+
+	md.WithBundleOnly().WithBundleOnly()
+	assert.True(t, md.IsBundleOnly())
+	assert.False(t, md.IsRejected())
+	assert.Equal(t, 0, md.MediaName.Port.Value)
+	assert.Equal(t, []Attribute{{Key: AttrKeyBundleOnly}}, md.Attributes)
+}
+
+func TestMovedOutOfBundle(t *testing.T) {
+	offer := getBundleTestSessionDescription()
+
+	answer := getBundleTestSessionDescription()
+	answer.Attributes = nil
+	answer.WithGroup(Group{Semantics: SemanticTokenBundle, MIDs: []string{"0"}})
+	answer.MediaDescriptions[1].Attributes = []Attribute{{Key: AttrKeyMID, Value: "1"}}
+	answer.MediaDescriptions[1].MediaName.Port.Value = 9
+	answer.MediaDescriptions[2].MediaName.Port.Value = 0
+
+	moved, err := MovedOutOfBundle(offer, answer)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1"}, moved)
+}
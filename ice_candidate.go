@@ -0,0 +1,214 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ICE candidate types.
+// https://datatracker.ietf.org/doc/html/rfc8839#section-5.1
+const (
+	ICECandidateTypeHost  = "host"
+	ICECandidateTypeSrflx = "srflx"
+	ICECandidateTypePrflx = "prflx"
+	ICECandidateTypeRelay = "relay"
+)
+
+const (
+	iceCandidateKeyTyp     = "typ"
+	iceCandidateKeyRaddr   = "raddr"
+	iceCandidateKeyRport   = "rport"
+	iceCandidateKeyTCPType = "tcptype"
+)
+
+var errInvalidICECandidate = errors.New("sdp: invalid ICE candidate")
+
+// ICECandidateAttribute is an extension attribute of an ICE candidate that is
+// not otherwise modelled by ICECandidate, such as "generation" or "ufrag".
+type ICECandidateAttribute struct {
+	Key   string
+	Value string
+}
+
+// ICECandidate represents the value of an "a=candidate" attribute.
+//
+//	candidate-attribute = "candidate" ":" foundation SP component-id SP
+//	                      transport SP priority SP connection-address SP port
+//	                      SP cand-type [SP rel-addr] [SP rel-port]
+//	                      *(SP cand-extension)
+//
+// https://datatracker.ietf.org/doc/html/rfc8839#section-5.1
+type ICECandidate struct {
+	Foundation string
+	Component  uint16
+	Transport  string
+	Priority   uint32
+	Address    string
+	Port       uint16
+	Typ        string
+
+	// RelatedAddress and RelatedPort are only present for server reflexive,
+	// peer reflexive and relayed candidates.
+	RelatedAddress string
+	RelatedPort    *uint16
+
+	// TCPType is set for TCP candidates.
+	// https://datatracker.ietf.org/doc/html/rfc6544#section-4.5
+	TCPType string
+
+	// ExtensionAttributes holds every other extension in the order it was
+	// read, so that unknown attributes survive a round trip.
+	ExtensionAttributes []ICECandidateAttribute
+}
+
+// Unmarshal creates an ICECandidate from a string. The "a=" and "candidate:"
+// prefixes are optional.
+func (c *ICECandidate) Unmarshal(raw string) error { //nolint:cyclop
+	raw = strings.TrimPrefix(strings.TrimSpace(raw), attributeKey)
+	raw = strings.TrimPrefix(raw, AttrKeyCandidate+":")
+
+	fields := strings.Fields(raw)
+	if len(fields) < 8 || fields[6] != iceCandidateKeyTyp {
+		return fmt.Errorf("%w: %v", errInvalidICECandidate, raw)
+	}
+
+	component, err := strconv.ParseUint(fields[1], 10, 16)
+	if err != nil {
+		return fmt.Errorf("%w: component `%v`", errInvalidICECandidate, fields[1])
+	}
+
+	priority, err := strconv.ParseUint(fields[3], 10, 32)
+	if err != nil {
+		return fmt.Errorf("%w: priority `%v`", errInvalidICECandidate, fields[3])
+	}
+
+	port, err := strconv.ParseUint(fields[5], 10, 16)
+	if err != nil {
+		return fmt.Errorf("%w: port `%v`", errInvalidICECandidate, fields[5])
+	}
+
+	candidate := ICECandidate{
+		Foundation: fields[0],
+		Component:  uint16(component),
+		Transport:  fields[2],
+		Priority:   uint32(priority),
+		Address:    fields[4],
+		Port:       uint16(port),
+		Typ:        fields[7],
+	}
+
+	rest := fields[8:]
+	if len(rest)%2 != 0 {
+		return fmt.Errorf("%w: extension without value `%v`", errInvalidICECandidate, rest[len(rest)-1])
+	}
+
+	for i := 0; i < len(rest); i += 2 {
+		key, value := rest[i], rest[i+1]
+		switch key {
+		case iceCandidateKeyRaddr:
+			candidate.RelatedAddress = value
+		case iceCandidateKeyRport:
+			relatedPort, err := strconv.ParseUint(value, 10, 16)
+			if err != nil {
+				return fmt.Errorf("%w: rport `%v`", errInvalidICECandidate, value)
+			}
+			rport := uint16(relatedPort)
+			candidate.RelatedPort = &rport
+		case iceCandidateKeyTCPType:
+			candidate.TCPType = value
+		default:
+			candidate.ExtensionAttributes = append(
+				candidate.ExtensionAttributes,
+				ICECandidateAttribute{Key: key, Value: value},
+			)
+		}
+	}
+
+	*c = candidate
+
+	return nil
+}
+
+// Marshal creates a string from an ICECandidate.
+func (c ICECandidate) Marshal() string {
+	return AttrKeyCandidate + ":" + c.String()
+}
+
+// String returns the value of the "a=candidate" attribute.
+func (c ICECandidate) String() string {
+	return stringFromMarshal(c.marshalInto, c.marshalSize)
+}
+
+func (c ICECandidate) marshalInto(b []byte) []byte {
+	b = append(append(b, c.Foundation...), ' ')
+	b = append(strconv.AppendUint(b, uint64(c.Component), 10), ' ')
+	b = append(append(b, c.Transport...), ' ')
+	b = append(strconv.AppendUint(b, uint64(c.Priority), 10), ' ')
+	b = append(append(b, c.Address...), ' ')
+	b = append(strconv.AppendUint(b, uint64(c.Port), 10), ' ')
+	b = append(append(b, iceCandidateKeyTyp+" "...), c.Typ...)
+
+	if c.RelatedAddress != "" {
+		b = append(append(b, " "+iceCandidateKeyRaddr+" "...), c.RelatedAddress...)
+	}
+	if c.RelatedPort != nil {
+		b = append(b, " "+iceCandidateKeyRport+" "...)
+		b = strconv.AppendUint(b, uint64(*c.RelatedPort), 10)
+	}
+	if c.TCPType != "" {
+		b = append(append(b, " "+iceCandidateKeyTCPType+" "...), c.TCPType...)
+	}
+	for _, a := range c.ExtensionAttributes {
+		b = append(append(append(append(b, ' '), a.Key...), ' '), a.Value...)
+	}
+
+	return b
+}
+
+func (c ICECandidate) marshalSize() (size int) {
+	size = len(c.Foundation) +
+		1 + lenUint(uint64(c.Component)) +
+		1 + len(c.Transport) +
+		1 + lenUint(uint64(c.Priority)) +
+		1 + len(c.Address) +
+		1 + lenUint(uint64(c.Port)) +
+		1 + len(iceCandidateKeyTyp) + 1 + len(c.Typ)
+
+	if c.RelatedAddress != "" {
+		size += 1 + len(iceCandidateKeyRaddr) + 1 + len(c.RelatedAddress)
+	}
+	if c.RelatedPort != nil {
+		size += 1 + len(iceCandidateKeyRport) + 1 + lenUint(uint64(*c.RelatedPort))
+	}
+	if c.TCPType != "" {
+		size += 1 + len(iceCandidateKeyTCPType) + 1 + len(c.TCPType)
+	}
+	for _, a := range c.ExtensionAttributes {
+		size += 1 + len(a.Key) + 1 + len(a.Value)
+	}
+
+	return size
+}
+
+// ICECandidates parses and returns the ICE candidates of the media description.
+func (d *MediaDescription) ICECandidates() ([]ICECandidate, error) {
+	var candidates []ICECandidate
+	for _, a := range d.Attributes {
+		if !a.IsICECandidate() {
+			continue
+		}
+
+		var candidate ICECandidate
+		if err := candidate.Unmarshal(a.Value); err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates, nil
+}
@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestICECandidate_RoundTrip(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{
+			name: "host",
+			raw:  "candidate:1 1 udp 2122260223 192.168.1.2 54321 typ host",
+			want: "1 1 udp 2122260223 192.168.1.2 54321 typ host",
+		},
+		{
+			name: "srflx with related address",
+			raw:  "a=candidate:842163049 1 udp 1677729535 203.0.113.7 3478 typ srflx raddr 0.0.0.0 rport 0",
+			want: "842163049 1 udp 1677729535 203.0.113.7 3478 typ srflx raddr 0.0.0.0 rport 0",
+		},
+		{
+			name: "tcp with unknown extensions",
+			raw:  "3 1 tcp 1518280447 192.168.1.2 9 typ host tcptype active generation 0 network-id 1",
+			want: "3 1 tcp 1518280447 192.168.1.2 9 typ host tcptype active generation 0 network-id 1",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var candidate ICECandidate
+			assert.NoError(t, candidate.Unmarshal(test.raw))
+			assert.Equal(t, test.want, candidate.String())
+			assert.Equal(t, "candidate:"+test.want, candidate.Marshal())
+			assert.Len(t, test.want, candidate.marshalSize())
+		})
+	}
+}
+
+func TestICECandidate_Unmarshal(t *testing.T) {
+	var candidate ICECandidate
+	assert.NoError(t, candidate.Unmarshal(
+		"4 2 tcp 1518280447 2001:db8::1 9 typ relay raddr 10.0.0.1 rport 3478 tcptype passive ufrag EsAw",
+	))
+
+	rport := uint16(3478)
+	assert.Equal(t, ICECandidate{
+		Foundation:     "4",
+		Component:      2,
+		Transport:      "tcp",
+		Priority:       1518280447,
+		Address:        "2001:db8::1",
+		Port:           9,
+		Typ:            ICECandidateTypeRelay,
+		RelatedAddress: "10.0.0.1",
+		RelatedPort:    &rport,
+		TCPType:        "passive",
+		ExtensionAttributes: []ICECandidateAttribute{
+			{Key: "ufrag", Value: "EsAw"},
+		},
+	}, candidate)
+}
+
+func TestICECandidate_Unmarshal_Error(t *testing.T) {
+	for _, raw := range []string{
+		"",
+		"1 1 udp 2122260223 192.168.1.2 54321",
+		"1 1 udp 2122260223 192.168.1.2 54321 host host",
+		"1 x udp 2122260223 192.168.1.2 54321 typ host",
+		"1 1 udp x 192.168.1.2 54321 typ host",
+		"1 1 udp 2122260223 192.168.1.2 70000 typ host",
+		"1 1 udp 2122260223 192.168.1.2 54321 typ srflx rport x",
+		"1 1 udp 2122260223 192.168.1.2 54321 typ host generation",
+	} {
+		var candidate ICECandidate
+		assert.ErrorIs(t, candidate.Unmarshal(raw), errInvalidICECandidate, raw)
+	}
+}
+
+func TestMediaDescription_ICECandidates(t *testing.T) {
+	host := ICECandidate{
+		Foundation: "1",
+		Component:  1,
+		Transport:  "udp",
+		Priority:   2122260223,
+		Address:    "192.168.1.2",
+		Port:       54321,
+		Typ:        ICECandidateTypeHost,
+	}
+
+	md := (&MediaDescription{}).
+		WithICECandidate(host).
+		WithPropertyAttribute(AttrKeyEndOfCandidates)
+	assert.Equal(t, []Attribute{
+		{Key: "candidate", Value: "1 1 udp 2122260223 192.168.1.2 54321 typ host"},
+		{Key: "end-of-candidates"},
+	}, md.Attributes)
+
+	candidates, err := md.ICECandidates()
+	assert.NoError(t, err)
+	assert.Equal(t, []ICECandidate{host}, candidates)
+
+	md.WithCandidate("invalid")
+	_, err = md.ICECandidates()
+	assert.ErrorIs(t, err, errInvalidICECandidate)
+}
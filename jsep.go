@@ -5,7 +5,6 @@ package sdp
 
 import (
 	"fmt"
-	"net/url"
 	"strconv"
 	"time"
 )
@@ -21,6 +20,7 @@ const (
 	AttrKeyMsid             = "msid"
 	AttrKeyMsidSemantic     = "msid-semantic"
 	AttrKeyConnectionSetup  = "setup"
+	AttrKeyFingerprint      = "fingerprint"
 	AttrKeyMID              = "mid"
 	AttrKeyICELite          = "ice-lite"
 	AttrKeyICEOptions       = "ice-options"
@@ -33,6 +33,9 @@ const (
 	AttrKeyExtMap           = "extmap"
 	AttrKeyExtMapAllowMixed = "extmap-allow-mixed"
 	AttrKeyCryptex          = "cryptex"
+	AttrKeyBundleOnly       = "bundle-only"
+	AttrKeyRID              = "rid"
+	AttrKeySimulcast        = "simulcast"
 )
 
 // Constants for semantic tokens used in JSEP.
@@ -43,10 +46,18 @@ const (
 	// https://datatracker.ietf.org/doc/html/rfc5956#section-4.1
 	SemanticTokenForwardErrorCorrectionFramework = "FEC-FR"
 	SemanticTokenWebRTCMediaStreams              = "WMS"
+	// https://datatracker.ietf.org/doc/html/rfc8843#section-7.1
+	SemanticTokenBundle = "BUNDLE"
+	// https://datatracker.ietf.org/doc/html/rfc7104#section-3.2
+	SemanticTokenDuplication = "DUP"
+	// SemanticTokenSimulcast groups the SSRCs of legacy simulcast.
+	SemanticTokenSimulcast = "SIM"
 )
 
 // Constants for extmap key.
 const (
+	// Deprecated: ID 3 is DefExtMapValueSDESMid. WithTransportCCExtMap uses
+	// DefExtMapValueTransportCC when it is free.
 	ExtMapValueTransportCC = 3
 )
 
@@ -149,7 +160,7 @@ func (s *SessionDescription) WithICERenomination() *SessionDescription {
 
 // WithFingerprint adds a fingerprint to the session description.
 func (s *SessionDescription) WithFingerprint(algorithm, value string) *SessionDescription {
-	return s.WithValueAttribute("fingerprint", algorithm+" "+value)
+	return s.WithValueAttribute(AttrKeyFingerprint, algorithm+" "+value)
 }
 
 // WithMedia adds a media description to the session description.
@@ -194,7 +205,7 @@ func (d *MediaDescription) WithValueAttribute(key, value string) *MediaDescripti
 
 // WithFingerprint adds a fingerprint to the media description.
 func (d *MediaDescription) WithFingerprint(algorithm, value string) *MediaDescription {
-	return d.WithValueAttribute("fingerprint", algorithm+" "+value)
+	return d.WithValueAttribute(AttrKeyFingerprint, algorithm+" "+value)
 }
 
 // WithICECredentials adds ICE credentials to the media description.
@@ -241,18 +252,27 @@ func (d *MediaDescription) WithCandidate(value string) *MediaDescription {
 	return d.WithValueAttribute("candidate", value)
 }
 
+// WithICECandidate adds an ICE candidate to the media description.
+func (d *MediaDescription) WithICECandidate(c ICECandidate) *MediaDescription {
+	return d.WithValueAttribute(AttrKeyCandidate, c.String())
+}
+
 // WithExtMap adds an extmap to the media description.
 func (d *MediaDescription) WithExtMap(e ExtMap) *MediaDescription {
 	return d.WithPropertyAttribute(e.Marshal())
 }
 
-// WithTransportCCExtMap adds an extmap to the media description.
+// WithTransportCCExtMap adds the transport-wide congestion control extmap to
+// the media description, unless it is already present. It gets the ID
+// DefExtMapValueTransportCC, or the lowest free ID when that one is in use by
+// another extension of the media description.
+//
+// The default ID changed from 3, ExtMapValueTransportCC, to 2. Peers that
+// relied on ID 3 must read the ID from the extmap attribute instead.
 func (d *MediaDescription) WithTransportCCExtMap() *MediaDescription {
-	uri, _ := url.Parse(extMapURI()[ExtMapValueTransportCC])
-	e := ExtMap{
-		Value: ExtMapValueTransportCC,
-		URI:   uri,
-	}
+	registry := &ExtMapRegistry{AllowMixed: d.AllowsMixedExtMaps(nil)}
+	registry.addMediaDescription(d)
+	_, _ = registry.AddExtMap(d, TransportCCURI)
 
-	return d.WithExtMap(e)
+	return d
 }
@@ -234,8 +234,21 @@ func TestMediaDescription_WithTransportCCExtMap_AddsExpectedAttribute(t *testing
 	ret := md.WithTransportCCExtMap()
 	assert.Same(t, md, ret)
 	if assert.Len(t, md.Attributes, 1) {
-		want := "extmap:3 " + extMapURI()[ExtMapValueTransportCC]
-		assert.Equal(t, want, md.Attributes[0].Key)
+		assert.Equal(t, "extmap:2 "+TransportCCURI, md.Attributes[0].Key)
 		assert.Empty(t, md.Attributes[0].Value)
 	}
+
+	md.WithTransportCCExtMap()
+	assert.Len(t, md.Attributes, 1)
+}
+
+func TestMediaDescription_WithTransportCCExtMap_DefaultIDInUse(t *testing.T) {
+	md := NewJSEPMediaDescription("video", nil)
+	md.WithValueAttribute(AttrKeyExtMap, "1 "+ABSSendTimeURI)
+	md.WithValueAttribute(AttrKeyExtMap, "2 "+SDESMidURI)
+
+	md.WithTransportCCExtMap()
+	if assert.Len(t, md.Attributes, 3) {
+		assert.Equal(t, "extmap:3 "+TransportCCURI, md.Attributes[2].Key)
+	}
 }
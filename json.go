@@ -0,0 +1,358 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+var errSDPTypeMissing = errors.New("sdp: missing SDP type")
+
+// SessionDescriptionInit is the envelope in which browsers exchange session
+// descriptions, encoded in JSON as {"type":"offer","sdp":"v=0\r\n..."}.
+// https://www.w3.org/TR/webrtc/#dom-rtcsessiondescriptioninit
+type SessionDescriptionInit struct {
+	Type SDPType `json:"type"`
+	SDP  string  `json:"sdp"`
+}
+
+// NewSessionDescriptionInit marshals the session description into an
+// envelope of the given type. s may be nil, which gives an empty sdp as used
+// by rollback.
+func NewSessionDescriptionInit(sdpType SDPType, s *SessionDescription) (SessionDescriptionInit, error) {
+	init := SessionDescriptionInit{Type: sdpType}
+	if s == nil {
+		return init, nil
+	}
+
+	raw, err := s.Marshal()
+	if err != nil {
+		return SessionDescriptionInit{}, err
+	}
+	init.SDP = string(raw)
+
+	return init, nil
+}
+
+// Unmarshal parses the sdp of the envelope.
+func (i SessionDescriptionInit) Unmarshal() (*SessionDescription, error) {
+	s := &SessionDescription{}
+	if err := s.UnmarshalString(i.SDP); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The type member is required
+// and must be one of the SDPType strings.
+func (i *SessionDescriptionInit) UnmarshalJSON(data []byte) error {
+	type sessionDescriptionInit SessionDescriptionInit
+	var init sessionDescriptionInit
+	if err := json.Unmarshal(data, &init); err != nil {
+		return err
+	}
+	if init.Type == SDPType(unknown) {
+		return errSDPTypeMissing
+	}
+	*i = SessionDescriptionInit(init)
+
+	return nil
+}
+
+// The JSON mapping of a SessionDescription mirrors the Go structure: every
+// field is a member named after it in camelCase, optional fields are
+// omitted when unset, and lists keep the order of the lines they hold. The
+// u= line is the string member "uri", and the session ID and version of the
+// o= line are decimal strings, as they exceed the integer precision of
+// JavaScript. Decoding the JSON of a session description and marshaling it
+// again gives the text the original marshals to. Text kept by
+// UnmarshalOptions.Preserve is not part of the mapping.
+//
+// The mapping has its own types, so that the MarshalText methods of the
+// field types, such as Origin and Attribute, do not turn their members into
+// strings.
+type jsonSessionDescription struct {
+	Version               Version                    `json:"version"`
+	Origin                jsonOrigin                 `json:"origin"`
+	SessionName           SessionName                `json:"sessionName"`
+	SessionInformation    *Information               `json:"sessionInformation,omitempty"`
+	URI                   string                     `json:"uri,omitempty"`
+	EmailAddress          *EmailAddress              `json:"emailAddress,omitempty"`
+	PhoneNumber           *PhoneNumber               `json:"phoneNumber,omitempty"`
+	ConnectionInformation *jsonConnectionInformation `json:"connectionInformation,omitempty"`
+	Bandwidth             []jsonBandwidth            `json:"bandwidth,omitempty"`
+	TimeDescriptions      []jsonTimeDescription      `json:"timeDescriptions,omitempty"`
+	TimeZones             []jsonTimeZone             `json:"timeZones,omitempty"`
+	EncryptionKey         *EncryptionKey             `json:"encryptionKey,omitempty"`
+	Attributes            []jsonAttribute            `json:"attributes,omitempty"`
+	MediaDescriptions     []*jsonMediaDescription    `json:"mediaDescriptions,omitempty"`
+}
+
+type jsonOrigin struct {
+	Username       string `json:"username"`
+	SessionID      uint64 `json:"sessionId,string"`
+	SessionVersion uint64 `json:"sessionVersion,string"`
+	NetworkType    string `json:"networkType"`
+	AddressType    string `json:"addressType"`
+	UnicastAddress string `json:"unicastAddress"`
+}
+
+type jsonConnectionInformation struct {
+	NetworkType string       `json:"networkType"`
+	AddressType string       `json:"addressType"`
+	Address     *jsonAddress `json:"address,omitempty"`
+}
+
+type jsonAddress struct {
+	Address string `json:"address"`
+	TTL     *int   `json:"ttl,omitempty"`
+	Range   *int   `json:"range,omitempty"`
+}
+
+type jsonBandwidth struct {
+	Experimental bool   `json:"experimental,omitempty"`
+	Type         string `json:"type"`
+	Bandwidth    uint64 `json:"bandwidth"`
+}
+
+type jsonTimeDescription struct {
+	Timing      jsonTiming       `json:"timing"`
+	RepeatTimes []jsonRepeatTime `json:"repeatTimes,omitempty"`
+}
+
+type jsonTiming struct {
+	StartTime uint64 `json:"startTime"`
+	StopTime  uint64 `json:"stopTime"`
+}
+
+type jsonRepeatTime struct {
+	Interval int64   `json:"interval"`
+	Duration int64   `json:"duration"`
+	Offsets  []int64 `json:"offsets"`
+}
+
+type jsonTimeZone struct {
+	AdjustmentTime uint64 `json:"adjustmentTime"`
+	Offset         int64  `json:"offset"`
+}
+
+type jsonAttribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value,omitempty"`
+}
+
+type jsonMediaDescription struct {
+	MediaName             jsonMediaName              `json:"mediaName"`
+	MediaTitle            *Information               `json:"mediaTitle,omitempty"`
+	ConnectionInformation *jsonConnectionInformation `json:"connectionInformation,omitempty"`
+	Bandwidth             []jsonBandwidth            `json:"bandwidth,omitempty"`
+	EncryptionKey         *EncryptionKey             `json:"encryptionKey,omitempty"`
+	Attributes            []jsonAttribute            `json:"attributes,omitempty"`
+}
+
+type jsonMediaName struct {
+	Media   string         `json:"media"`
+	Port    jsonRangedPort `json:"port"`
+	Protos  []string       `json:"protos"`
+	Formats []string       `json:"formats"`
+}
+
+type jsonRangedPort struct {
+	Value int  `json:"value"`
+	Range *int `json:"range,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s SessionDescription) MarshalJSON() ([]byte, error) {
+	out := jsonSessionDescription{
+		Version:               s.Version,
+		Origin:                jsonOrigin(s.Origin),
+		SessionName:           s.SessionName,
+		SessionInformation:    s.SessionInformation,
+		EmailAddress:          s.EmailAddress,
+		PhoneNumber:           s.PhoneNumber,
+		ConnectionInformation: toJSONConnectionInformation(s.ConnectionInformation),
+		Bandwidth:             convertSlice(s.Bandwidth, func(b Bandwidth) jsonBandwidth { return jsonBandwidth(b) }),
+		TimeDescriptions:      convertSlice(s.TimeDescriptions, toJSONTimeDescription),
+		TimeZones:             convertSlice(s.TimeZones, func(z TimeZone) jsonTimeZone { return jsonTimeZone(z) }),
+		EncryptionKey:         s.EncryptionKey,
+		Attributes:            convertSlice(s.Attributes, func(a Attribute) jsonAttribute { return jsonAttribute(a) }),
+		MediaDescriptions:     convertSlice(s.MediaDescriptions, toJSONMediaDescription),
+	}
+	if s.URI != nil {
+		out.URI = s.URI.String()
+	}
+
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, see MarshalJSON for the mapping.
+func (s *SessionDescription) UnmarshalJSON(data []byte) error {
+	var in jsonSessionDescription
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	desc := SessionDescription{
+		Version:               in.Version,
+		Origin:                Origin(in.Origin),
+		SessionName:           in.SessionName,
+		SessionInformation:    in.SessionInformation,
+		EmailAddress:          in.EmailAddress,
+		PhoneNumber:           in.PhoneNumber,
+		ConnectionInformation: fromJSONConnectionInformation(in.ConnectionInformation),
+		Bandwidth:             convertSlice(in.Bandwidth, func(b jsonBandwidth) Bandwidth { return Bandwidth(b) }),
+		TimeDescriptions:      convertSlice(in.TimeDescriptions, fromJSONTimeDescription),
+		TimeZones:             convertSlice(in.TimeZones, func(z jsonTimeZone) TimeZone { return TimeZone(z) }),
+		EncryptionKey:         in.EncryptionKey,
+		Attributes:            convertSlice(in.Attributes, func(a jsonAttribute) Attribute { return Attribute(a) }),
+		MediaDescriptions:     convertSlice(in.MediaDescriptions, fromJSONMediaDescription),
+	}
+
+	if in.URI != "" {
+		uri, err := url.Parse(in.URI)
+		if err != nil {
+			return fmt.Errorf("%w `%v`: %w", ErrSDPInvalidValue, in.URI, err)
+		}
+		desc.URI = uri
+	}
+	*s = desc
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler with the mapping of the media
+// descriptions of SessionDescription.MarshalJSON.
+func (d MediaDescription) MarshalJSON() ([]byte, error) {
+	return json.Marshal(toJSONMediaDescription(&d))
+}
+
+// UnmarshalJSON implements json.Unmarshaler, see MarshalJSON for the mapping.
+func (d *MediaDescription) UnmarshalJSON(data []byte) error {
+	var in jsonMediaDescription
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	*d = *fromJSONMediaDescription(&in)
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler with the mapping of the time
+// descriptions of SessionDescription.MarshalJSON.
+func (t TimeDescription) MarshalJSON() ([]byte, error) {
+	return json.Marshal(toJSONTimeDescription(t))
+}
+
+// UnmarshalJSON implements json.Unmarshaler, see MarshalJSON for the mapping.
+func (t *TimeDescription) UnmarshalJSON(data []byte) error {
+	var in jsonTimeDescription
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	*t = fromJSONTimeDescription(in)
+
+	return nil
+}
+
+func toJSONMediaDescription(md *MediaDescription) *jsonMediaDescription {
+	if md == nil {
+		return nil
+	}
+
+	return &jsonMediaDescription{
+		MediaName: jsonMediaName{
+			Media:   md.MediaName.Media,
+			Port:    jsonRangedPort(md.MediaName.Port),
+			Protos:  md.MediaName.Protos,
+			Formats: md.MediaName.Formats,
+		},
+		MediaTitle:            md.MediaTitle,
+		ConnectionInformation: toJSONConnectionInformation(md.ConnectionInformation),
+		Bandwidth:             convertSlice(md.Bandwidth, func(b Bandwidth) jsonBandwidth { return jsonBandwidth(b) }),
+		EncryptionKey:         md.EncryptionKey,
+		Attributes:            convertSlice(md.Attributes, func(a Attribute) jsonAttribute { return jsonAttribute(a) }),
+	}
+}
+
+func fromJSONMediaDescription(md *jsonMediaDescription) *MediaDescription {
+	if md == nil {
+		return nil
+	}
+
+	return &MediaDescription{
+		MediaName: MediaName{
+			Media:   md.MediaName.Media,
+			Port:    RangedPort(md.MediaName.Port),
+			Protos:  md.MediaName.Protos,
+			Formats: md.MediaName.Formats,
+		},
+		MediaTitle:            md.MediaTitle,
+		ConnectionInformation: fromJSONConnectionInformation(md.ConnectionInformation),
+		Bandwidth:             convertSlice(md.Bandwidth, func(b jsonBandwidth) Bandwidth { return Bandwidth(b) }),
+		EncryptionKey:         md.EncryptionKey,
+		Attributes:            convertSlice(md.Attributes, func(a jsonAttribute) Attribute { return Attribute(a) }),
+	}
+}
+
+func toJSONTimeDescription(t TimeDescription) jsonTimeDescription {
+	return jsonTimeDescription{
+		Timing:      jsonTiming(t.Timing),
+		RepeatTimes: convertSlice(t.RepeatTimes, func(r RepeatTime) jsonRepeatTime { return jsonRepeatTime(r) }),
+	}
+}
+
+func fromJSONTimeDescription(t jsonTimeDescription) TimeDescription {
+	return TimeDescription{
+		Timing:      Timing(t.Timing),
+		RepeatTimes: convertSlice(t.RepeatTimes, func(r jsonRepeatTime) RepeatTime { return RepeatTime(r) }),
+	}
+}
+
+func toJSONConnectionInformation(c *ConnectionInformation) *jsonConnectionInformation {
+	if c == nil {
+		return nil
+	}
+
+	out := &jsonConnectionInformation{NetworkType: c.NetworkType, AddressType: c.AddressType}
+	if c.Address != nil {
+		address := jsonAddress(*c.Address)
+		out.Address = &address
+	}
+
+	return out
+}
+
+func fromJSONConnectionInformation(c *jsonConnectionInformation) *ConnectionInformation {
+	if c == nil {
+		return nil
+	}
+
+	out := &ConnectionInformation{NetworkType: c.NetworkType, AddressType: c.AddressType}
+	if c.Address != nil {
+		address := Address(*c.Address)
+		out.Address = &address
+	}
+
+	return out
+}
+
+// convertSlice converts every element of in, keeping nil and empty slices
+// nil as they are omitted from the JSON.
+func convertSlice[T, U any](in []T, convert func(T) U) []U {
+	if len(in) == 0 {
+		return nil
+	}
+
+	out := make([]U, len(in))
+	for i, v := range in {
+		out[i] = convert(v)
+	}
+
+	return out
+}
@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const jsonSDP = "v=0\r\n" +
+	"o=jdoe 18446744073709551615 9007199254740993 IN IP4 10.47.16.5\r\n" +
+	"s=SDP Seminar\r\n" +
+	"i=A Seminar on the session description protocol\r\n" +
+	"u=http://www.example.com/seminars/sdp.pdf\r\n" +
+	"e=j.doe@example.com (Jane Doe)\r\n" +
+	"p=+1 617 555-6011\r\n" +
+	"c=IN IP4 224.2.17.12/127/2\r\n" +
+	"b=X-YZ:128\r\n" +
+	"t=2873397496 2873404696\r\n" +
+	"r=604800 3600 0 90000\r\n" +
+	"z=2882844526 -3600 2898848070 0\r\n" +
+	"k=prompt\r\n" +
+	"a=recvonly\r\n" +
+	"m=audio 49170/2 RTP/AVP 0\r\n" +
+	"i=Vivamus a posuere nisl\r\n" +
+	"c=IN IP6 ff15::103/3\r\n" +
+	"b=AS:64\r\n" +
+	"a=rtpmap:0 PCMU/8000\r\n" +
+	"a=rtcp-mux\r\n"
+
+func TestSessionDescription_JSON(t *testing.T) {
+	var s SessionDescription
+	assert.NoError(t, s.UnmarshalString(jsonSDP))
+	// The parser keeps the TTL and range in the address, set them apart.
+	ttl, addressRange := 127, 2
+	s.ConnectionInformation.Address = &Address{Address: "224.2.17.12", TTL: &ttl, Range: &addressRange}
+
+	data, err := json.Marshal(s)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"sessionId":"18446744073709551615"`)
+	assert.Contains(t, string(data), `"uri":"http://www.example.com/seminars/sdp.pdf"`)
+	assert.Contains(t, string(data), `"address":{"address":"224.2.17.12","ttl":127,"range":2}`)
+	assert.Contains(t, string(data), `{"key":"rtcp-mux"}`)
+
+	pointerData, err := json.Marshal(&s)
+	assert.NoError(t, err)
+	assert.Equal(t, data, pointerData)
+
+	var decoded SessionDescription
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	raw, err := decoded.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, jsonSDP, string(raw))
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"version":0,"origin":{"username":"-","sessionId":"1",`+
+		`"sessionVersion":"2","networkType":"IN","addressType":"IP4","unicastAddress":"127.0.0.1"},"sessionName":"-"}`),
+		&decoded))
+	assert.Nil(t, decoded.URI)
+	assert.Empty(t, decoded.MediaDescriptions)
+	assert.Equal(t, uint64(2), decoded.Origin.SessionVersion)
+
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"uri":"http://[::1"}`), &decoded), ErrSDPInvalidValue)
+	assert.Error(t, json.Unmarshal([]byte(`{"origin":{"sessionId":1}}`), &decoded))
+}
+
+func TestSessionDescriptionInit(t *testing.T) {
+	var s SessionDescription
+	assert.NoError(t, s.UnmarshalString(jsonSDP))
+
+	init, err := NewSessionDescriptionInit(SDPTypeOffer, &s)
+	assert.NoError(t, err)
+	data, err := json.Marshal(init)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `{"type":"offer","sdp":"v=0\r\no=jdoe `)
+
+	var decoded SessionDescriptionInit
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, init, decoded)
+	parsed, err := decoded.Unmarshal()
+	assert.NoError(t, err)
+	assert.True(t, parsed.Equal(&s, EqualOptions{}))
+
+	rollback, err := NewSessionDescriptionInit(SDPTypeRollback, nil)
+	assert.NoError(t, err)
+	data, err = json.Marshal(rollback)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"type":"rollback","sdp":""}`, string(data))
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"type":"answer"}`), &decoded))
+	assert.Equal(t, SessionDescriptionInit{Type: SDPTypeAnswer}, decoded)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"sdp":"v=0"}`), &decoded), errSDPTypeMissing)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"type":"Offer"}`), &decoded), errSDPTypeString)
+	_, err = json.Marshal(SessionDescriptionInit{})
+	assert.ErrorIs(t, err, errSDPTypeString)
+}
+
+func TestMediaDescription_JSON(t *testing.T) {
+	var s SessionDescription
+	assert.NoError(t, s.UnmarshalString(jsonSDP))
+
+	data, err := json.Marshal(s.MediaDescriptions[0])
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"mediaName":{"media":"audio","port":{"value":49170,"range":2},`+
+		`"protos":["RTP","AVP"],"formats":["0"]}`)
+	assert.Contains(t, string(data), `"connectionInformation":{"networkType":"IN","addressType":"IP6",`+
+		`"address":{"address":"ff15::103/3"}}`)
+	assert.Contains(t, string(data), `"attributes":[{"key":"rtpmap","value":"0 PCMU/8000"},{"key":"rtcp-mux"}]`)
+
+	var decoded MediaDescription
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, *s.MediaDescriptions[0], decoded)
+
+	data, err = json.Marshal(s.TimeDescriptions[0])
+	assert.NoError(t, err)
+	assert.Equal(t, `{"timing":{"startTime":2873397496,"stopTime":2873404696},`+
+		`"repeatTimes":[{"interval":604800,"duration":3600,"offsets":[0,90000]}]}`, string(data))
+
+	var decodedTime TimeDescription
+	assert.NoError(t, json.Unmarshal(data, &decodedTime))
+	assert.Equal(t, s.TimeDescriptions[0], decodedTime)
+}
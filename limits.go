@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Limits bounds the resources used to parse a session description, which
+// protects parsers of untrusted input. A zero value disables the limit.
+type Limits struct {
+	// MaxSize is the maximum size of the session description in bytes.
+	MaxSize int
+
+	// MaxLineLength is the maximum length of a line in bytes, without the
+	// line ending.
+	MaxLineLength int
+
+	// MaxMediaDescriptions is the maximum number of m-sections.
+	MaxMediaDescriptions int
+
+	// MaxAttributes is the maximum number of attributes of the session and
+	// of each m-section.
+	MaxAttributes int
+
+	// MaxFormats is the maximum number of formats of each m= line.
+	MaxFormats int
+
+	// MaxCandidates is the maximum number of "a=candidate" attributes of
+	// the whole session description.
+	MaxCandidates int
+
+	// MaxRepeatTimes is the maximum number of r= lines of each t= line.
+	MaxRepeatTimes int
+}
+
+// LimitError is returned when a session description exceeds one of the
+// Limits. Parsing is aborted even in lenient mode.
+type LimitError struct {
+	// Limit is the name of the exceeded field of Limits, such as
+	// "MaxLineLength".
+	Limit string
+	// Max is the value of the exceeded limit.
+	Max int
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("sdp: limit %s of %d exceeded", e.Limit, e.Max)
+}
+
+// checkLimit returns a LimitError when count exceeds a non-zero limit.
+func checkLimit(name string, limit, count int) error {
+	if limit > 0 && count > limit {
+		return &LimitError{Limit: name, Max: limit}
+	}
+
+	return nil
+}
+
+// checkAttributeLimits checks the limits that apply to an attribute line
+// before the attribute is stored.
+func (l *lexer) checkAttributeLimits(count int, line string) error {
+	if err := checkLimit("MaxAttributes", l.options.Limits.MaxAttributes, count); err != nil {
+		return err
+	}
+
+	if strings.HasPrefix(line, AttrKeyCandidate+":") {
+		l.candidates++
+		if err := checkLimit("MaxCandidates", l.options.Limits.MaxCandidates, l.candidates); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkLineLength checks the length of the line starting at lineStart before
+// it is read.
+func (l *baseLexer) checkLineLength() error {
+	if l.maxLineLength <= 0 {
+		return nil
+	}
+
+	line := l.value[l.lineStart:]
+	if end := strings.IndexByte(line, '\n'); end >= 0 {
+		line = line[:end]
+	}
+
+	return checkLimit("MaxLineLength", l.maxLineLength, len(strings.TrimSuffix(line, "\r")))
+}
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const limitsSDP = "v=0\r\n" +
+	"o=- 123 1 IN IP4 127.0.0.1\r\n" +
+	"s=-\r\n" +
+	"t=0 0\r\n" +
+	"r=604800 3600 0 90000\r\n" +
+	"r=604800 3600 0\r\n" +
+	"a=group:BUNDLE 0 1\r\n" +
+	"a=ice-lite\r\n" +
+	"m=audio 9 RTP/AVP 0 8 9\r\n" +
+	"a=mid:0\r\n" +
+	"a=candidate:1 1 udp 2122260223 192.168.1.10 54400 typ host\r\n" +
+	"a=candidate:2 1 udp 2122260223 192.168.1.11 54400 typ host\r\n" +
+	"m=video 9 RTP/AVP 96\r\n" +
+	"a=mid:1\r\n" +
+	"a=candidate:1 1 udp 2122260223 192.168.1.10 54402 typ host\r\n"
+
+func TestLimits(t *testing.T) {
+	for _, test := range []struct {
+		limits Limits
+		name   string
+		line   int
+	}{
+		{Limits{MaxSize: len(limitsSDP) - 1}, "MaxSize", 0},
+		{Limits{MaxLineLength: 57}, "MaxLineLength", 11},
+		{Limits{MaxMediaDescriptions: 1}, "MaxMediaDescriptions", 13},
+		{Limits{MaxAttributes: 1}, "MaxAttributes", 8},
+		{Limits{MaxFormats: 2}, "MaxFormats", 9},
+		{Limits{MaxCandidates: 2}, "MaxCandidates", 15},
+		{Limits{MaxRepeatTimes: 1}, "MaxRepeatTimes", 6},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var sd SessionDescription
+			_, err := sd.UnmarshalStringWithOptions(limitsSDP, UnmarshalOptions{Limits: test.limits})
+
+			var limitErr *LimitError
+			if assert.ErrorAs(t, err, &limitErr) {
+				assert.Equal(t, test.name, limitErr.Limit)
+			}
+
+			var parseErr *ParseError
+			if test.line > 0 && assert.ErrorAs(t, err, &parseErr) {
+				assert.Equal(t, test.line, parseErr.Line)
+			}
+
+			// Limits are not recovered from in lenient mode.
+			_, err = sd.UnmarshalStringWithOptions(limitsSDP, UnmarshalOptions{Lenient: true, Limits: test.limits})
+			assert.ErrorAs(t, err, &limitErr)
+		})
+	}
+}
+
+func TestLimits_NotExceeded(t *testing.T) {
+	var sd SessionDescription
+	_, err := sd.UnmarshalStringWithOptions(limitsSDP, UnmarshalOptions{Limits: Limits{
+		MaxSize:              len(limitsSDP),
+		MaxLineLength:        58,
+		MaxMediaDescriptions: 2,
+		MaxAttributes:        3,
+		MaxFormats:           3,
+		MaxCandidates:        3,
+		MaxRepeatTimes:       2,
+	}})
+	assert.NoError(t, err)
+}
+
+func TestLimits_LineLengthWithoutLineEnding(t *testing.T) {
+	var sd SessionDescription
+	_, err := sd.UnmarshalStringWithOptions(
+		"v=0\r\n"+strings.Repeat("x", 100),
+		UnmarshalOptions{Limits: Limits{MaxLineLength: 10}},
+	)
+
+	var limitErr *LimitError
+	assert.ErrorAs(t, err, &limitErr)
+	assert.Equal(t, "sdp: limit MaxLineLength of 10 exceeded", limitErr.Error())
+}
@@ -37,8 +37,26 @@ package sdp
 //	b=* (zero or more bandwidth information lines)
 //	k=* (encryption key)
 //	a=* (zero or more media attribute lines)
-func (s *SessionDescription) Marshal() ([]byte, error) { //nolint:cyclop
-	marsh := make(marshaller, 0, s.MarshalSize())
+//
+// A description parsed with UnmarshalOptions.Preserve is marshaled from its
+// original text, see UnmarshalOptions.
+func (s *SessionDescription) Marshal() ([]byte, error) {
+	if s.preserved != nil {
+		return s.preserved.marshal(s)
+	}
+
+	marsh := s.marshalSessionInto(make([]byte, 0, s.MarshalSize()))
+	for _, md := range s.MediaDescriptions {
+		marsh = md.marshalInto(marsh)
+	}
+
+	return marsh, nil
+}
+
+// marshalSessionInto appends the session-level lines, up to the first m=
+// line.
+func (s *SessionDescription) marshalSessionInto(buf []byte) []byte { //nolint:cyclop
+	marsh := marshaller(buf)
 
 	marsh.addKeyValue("v=", s.Version.marshalInto)
 	marsh.addKeyValue("o=", s.Origin.marshalInto)
@@ -96,38 +114,58 @@ func (s *SessionDescription) Marshal() ([]byte, error) { //nolint:cyclop
 		marsh.addKeyValue("a=", a.marshalInto)
 	}
 
-	for _, md := range s.MediaDescriptions {
-		marsh.addKeyValue("m=", md.MediaName.marshalInto)
+	return marsh
+}
 
-		if md.MediaTitle != nil {
-			marsh.addKeyValue("i=", md.MediaTitle.marshalInto)
-		}
+// marshalInto appends the lines of the media description.
+func (d *MediaDescription) marshalInto(buf []byte) []byte {
+	marsh := marshaller(buf)
 
-		if md.ConnectionInformation != nil {
-			marsh.addKeyValue("c=", md.ConnectionInformation.marshalInto)
-		}
+	marsh.addKeyValue("m=", d.MediaName.marshalInto)
 
-		for _, b := range md.Bandwidth {
-			marsh.addKeyValue("b=", b.marshalInto)
-		}
+	if d.MediaTitle != nil {
+		marsh.addKeyValue("i=", d.MediaTitle.marshalInto)
+	}
 
-		if md.EncryptionKey != nil {
-			marsh.addKeyValue("k=", md.EncryptionKey.marshalInto)
-		}
+	if d.ConnectionInformation != nil {
+		marsh.addKeyValue("c=", d.ConnectionInformation.marshalInto)
+	}
 
-		for _, a := range md.Attributes {
-			marsh.addKeyValue("a=", a.marshalInto)
-		}
+	for _, b := range d.Bandwidth {
+		marsh.addKeyValue("b=", b.marshalInto)
 	}
 
-	return marsh, nil
+	if d.EncryptionKey != nil {
+		marsh.addKeyValue("k=", d.EncryptionKey.marshalInto)
+	}
+
+	for _, a := range d.Attributes {
+		marsh.addKeyValue("a=", a.marshalInto)
+	}
+
+	return marsh
 }
 
 // `$type=` and CRLF size.
 const lineBaseSize = 4
 
 // MarshalSize returns the size of the SessionDescription once marshaled.
-func (s *SessionDescription) MarshalSize() (marshalSize int) { //nolint:cyclop
+func (s *SessionDescription) MarshalSize() (marshalSize int) {
+	if s.preserved != nil {
+		if size, err := s.preserved.marshalSize(s); err == nil {
+			return size
+		}
+	}
+
+	marshalSize = s.marshalSessionSize()
+	for _, md := range s.MediaDescriptions {
+		marshalSize += md.marshalSize()
+	}
+
+	return marshalSize
+}
+
+func (s *SessionDescription) marshalSessionSize() (marshalSize int) { //nolint:cyclop
 	marshalSize += lineBaseSize + s.Version.marshalSize()
 	marshalSize += lineBaseSize + s.Origin.marshalSize()
 	marshalSize += lineBaseSize + s.SessionName.marshalSize()
@@ -182,26 +220,28 @@ func (s *SessionDescription) MarshalSize() (marshalSize int) { //nolint:cyclop
 		marshalSize += lineBaseSize + a.marshalSize()
 	}
 
-	for _, md := range s.MediaDescriptions {
-		marshalSize += lineBaseSize + md.MediaName.marshalSize()
-		if md.MediaTitle != nil {
-			marshalSize += lineBaseSize + md.MediaTitle.marshalSize()
-		}
-		if md.ConnectionInformation != nil {
-			marshalSize += lineBaseSize + md.ConnectionInformation.marshalSize()
-		}
+	return marshalSize
+}
 
-		for _, b := range md.Bandwidth {
-			marshalSize += lineBaseSize + b.marshalSize()
-		}
+func (d *MediaDescription) marshalSize() (marshalSize int) {
+	marshalSize += lineBaseSize + d.MediaName.marshalSize()
+	if d.MediaTitle != nil {
+		marshalSize += lineBaseSize + d.MediaTitle.marshalSize()
+	}
+	if d.ConnectionInformation != nil {
+		marshalSize += lineBaseSize + d.ConnectionInformation.marshalSize()
+	}
 
-		if md.EncryptionKey != nil {
-			marshalSize += lineBaseSize + md.EncryptionKey.marshalSize()
-		}
+	for _, b := range d.Bandwidth {
+		marshalSize += lineBaseSize + b.marshalSize()
+	}
 
-		for _, a := range md.Attributes {
-			marshalSize += lineBaseSize + a.marshalSize()
-		}
+	if d.EncryptionKey != nil {
+		marshalSize += lineBaseSize + d.EncryptionKey.marshalSize()
+	}
+
+	for _, a := range d.Attributes {
+		marshalSize += lineBaseSize + a.marshalSize()
 	}
 
 	return marshalSize
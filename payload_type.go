@@ -0,0 +1,221 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	errPayloadTypesExhausted = errors.New("sdp: no dynamic payload type left")
+	errInvalidPayloadType    = errors.New("sdp: invalid payload type")
+	errPayloadTypeCollision  = errors.New("sdp: payload types collide")
+)
+
+// PayloadTypeAllocator hands out the dynamic RTP payload types that are not
+// in use. Payload types 96–127 are allocated first, then 35–63. The range
+// 64–95 is never allocated, as it clashes with RTCP packet types when RTP
+// and RTCP are multiplexed. The zero value has no payload type in use.
+// https://datatracker.ietf.org/doc/html/rfc3551#section-6
+// https://datatracker.ietf.org/doc/html/rfc5761#section-4
+type PayloadTypeAllocator struct {
+	used [128]bool
+}
+
+// Reserve marks the payload types as in use.
+func (a *PayloadTypeAllocator) Reserve(payloadTypes ...uint8) {
+	for _, payloadType := range payloadTypes {
+		if payloadType < uint8(len(a.used)) {
+			a.used[payloadType] = true
+		}
+	}
+}
+
+// ReserveSessionDescription marks the payload types of every media
+// description of s as in use.
+func (a *PayloadTypeAllocator) ReserveSessionDescription(s *SessionDescription) {
+	for _, md := range s.MediaDescriptions {
+		a.Reserve(md.PayloadTypes()...)
+	}
+}
+
+// InUse reports whether the payload type is in use.
+func (a *PayloadTypeAllocator) InUse(payloadType uint8) bool {
+	return payloadType < uint8(len(a.used)) && a.used[payloadType]
+}
+
+// Allocate returns a dynamic payload type that is not in use and marks it
+// as in use.
+func (a *PayloadTypeAllocator) Allocate() (uint8, error) {
+	for _, payloadTypes := range [][2]uint8{{96, 127}, {35, 63}} {
+		for payloadType := payloadTypes[0]; payloadType <= payloadTypes[1]; payloadType++ {
+			if !a.used[payloadType] {
+				a.used[payloadType] = true
+
+				return payloadType, nil
+			}
+		}
+	}
+
+	return 0, errPayloadTypesExhausted
+}
+
+// PayloadTypes returns the payload types of the m= line. Formats that are not
+// payload types, such as those of data channels, are skipped.
+func (d *MediaDescription) PayloadTypes() []uint8 {
+	var payloadTypes []uint8
+	for _, format := range d.MediaName.Formats {
+		if payloadType, err := parsePayloadType(format); err == nil {
+			payloadTypes = append(payloadTypes, payloadType)
+		}
+	}
+
+	return payloadTypes
+}
+
+func parsePayloadType(value string) (uint8, error) {
+	payloadType, err := strconv.ParseUint(value, 10, 7)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", errInvalidPayloadType, value)
+	}
+
+	return uint8(payloadType), nil
+}
+
+// RemapPayloadTypes replaces the payload types of the media description as
+// given by mapping, which maps old payload types to new ones. Payload types
+// are replaced together in the m= line, in the rtpmap, fmtp and rtcp-fb
+// attributes, in the apt parameter of RTX, in the redundancy list of RED and
+// in the pt parameter of rid attributes. Payload types that are not mapped
+// are kept, and mappings of payload types that are not on the m= line are
+// ignored. Payload types may be swapped. The media description is not
+// modified when the new payload types are invalid or collide with each other
+// or with a payload type that is only referenced by an attribute.
+func (d *MediaDescription) RemapPayloadTypes(mapping map[uint8]uint8) error {
+	used := map[uint8]uint8{}
+	for _, payloadType := range d.PayloadTypes() {
+		if newPayloadType, ok := mapping[payloadType]; ok {
+			used[payloadType] = newPayloadType
+		}
+	}
+
+	remapped := map[uint8]bool{}
+	for _, payloadType := range d.referencedPayloadTypes() {
+		newPayloadType, ok := used[payloadType]
+		if !ok {
+			newPayloadType = payloadType
+		}
+		if newPayloadType > 127 {
+			return fmt.Errorf("%w: %d", errInvalidPayloadType, newPayloadType)
+		}
+		if remapped[newPayloadType] {
+			return fmt.Errorf("%w: %d", errPayloadTypeCollision, newPayloadType)
+		}
+		remapped[newPayloadType] = true
+	}
+
+	remap := func(value string) string {
+		payloadType, err := parsePayloadType(value)
+		if err != nil {
+			return value
+		}
+		if newPayloadType, ok := used[payloadType]; ok {
+			return strconv.Itoa(int(newPayloadType))
+		}
+
+		return value
+	}
+
+	for i, format := range d.MediaName.Formats {
+		d.MediaName.Formats[i] = remap(format)
+	}
+
+	codecs := d.codecMap()
+	for i, a := range d.Attributes {
+		switch a.Key {
+		case attrKeyRtpmap, attrKeyRtcpFb:
+			payloadType, rest, found := strings.Cut(a.Value, " ")
+			if found {
+				d.Attributes[i].Value = remap(payloadType) + " " + rest
+			}
+		case attrKeyFmtp:
+			payloadType, parameters, found := strings.Cut(a.Value, " ")
+			if !found {
+				continue
+			}
+			if pt, err := parsePayloadType(payloadType); err == nil && strings.EqualFold(codecs[pt].Name, "red") {
+				parameters = remapList(parameters, "/", remap)
+			} else {
+				parameters = remapParameter(parameters, "apt", remap)
+			}
+			d.Attributes[i].Value = remap(payloadType) + " " + parameters
+		case AttrKeyRID:
+			id, parameters, _ := strings.Cut(a.Value, " ")
+			direction, parameters, found := strings.Cut(parameters, " ")
+			if found {
+				parameters = remapParameter(parameters, "pt", func(value string) string {
+					return remapList(value, ",", remap)
+				})
+				d.Attributes[i].Value = id + " " + direction + " " + parameters
+			}
+		}
+	}
+
+	return nil
+}
+
+// referencedPayloadTypes returns the payload types of the m= line followed by
+// those only declared by rtpmap, fmtp or rtcp-fb attributes, once each.
+func (d *MediaDescription) referencedPayloadTypes() []uint8 {
+	payloadTypes := d.PayloadTypes()
+	for _, a := range d.Attributes {
+		if a.Key != attrKeyRtpmap && a.Key != attrKeyFmtp && a.Key != attrKeyRtcpFb {
+			continue
+		}
+
+		value, _, _ := strings.Cut(a.Value, " ")
+		payloadType, err := parsePayloadType(value)
+		if err == nil && !slices.Contains(payloadTypes, payloadType) {
+			payloadTypes = append(payloadTypes, payloadType)
+		}
+	}
+
+	return payloadTypes
+}
+
+// remapParameter replaces the value of the parameter key of the
+// ";"-separated parameters, keeping their formatting. The space around the
+// key and the value is ignored.
+func remapParameter(parameters, key string, remap func(string) string) string {
+	split := strings.Split(parameters, ";")
+	for i, parameter := range split {
+		name, value, found := strings.Cut(parameter, "=")
+		if found && strings.EqualFold(strings.TrimSpace(name), key) {
+			split[i] = name + "=" + remapTrimmed(value, remap)
+		}
+	}
+
+	return strings.Join(split, ";")
+}
+
+// remapTrimmed remaps value without the space around it, which is kept.
+func remapTrimmed(value string, remap func(string) string) string {
+	trimmed := strings.TrimSpace(value)
+	start := strings.Index(value, trimmed)
+
+	return value[:start] + remap(trimmed) + value[start+len(trimmed):]
+}
+
+func remapList(list, separator string, remap func(string) string) string {
+	split := strings.Split(list, separator)
+	for i, value := range split {
+		split[i] = remapTrimmed(value, remap)
+	}
+
+	return strings.Join(split, separator)
+}
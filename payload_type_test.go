@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayloadTypeAllocator(t *testing.T) {
+	var allocator PayloadTypeAllocator
+	allocator.Reserve(96, 98, 200)
+	assert.True(t, allocator.InUse(96))
+	assert.False(t, allocator.InUse(97))
+	assert.False(t, allocator.InUse(200))
+
+	payloadType, err := allocator.Allocate()
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(97), payloadType)
+	payloadType, err = allocator.Allocate()
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(99), payloadType)
+
+	for payloadType := 100; payloadType <= 127; payloadType++ {
+		_, err = allocator.Allocate()
+		assert.NoError(t, err)
+	}
+	payloadType, err = allocator.Allocate()
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(35), payloadType)
+
+	for payloadType := 36; payloadType <= 63; payloadType++ {
+		_, err = allocator.Allocate()
+		assert.NoError(t, err)
+	}
+	_, err = allocator.Allocate()
+	assert.ErrorIs(t, err, errPayloadTypesExhausted)
+	assert.False(t, allocator.InUse(64))
+}
+
+func TestPayloadTypeAllocator_ReserveSessionDescription(t *testing.T) {
+	var session SessionDescription
+	assert.NoError(t, session.UnmarshalString(diffOfferSDP))
+	session.WithMedia(&MediaDescription{MediaName: MediaName{
+		Media: "application", Formats: []string{"webrtc-datachannel"},
+	}})
+	assert.Equal(t, []uint8{111, 0}, session.MediaDescriptions[0].PayloadTypes())
+	assert.Empty(t, session.MediaDescriptions[2].PayloadTypes())
+
+	var allocator PayloadTypeAllocator
+	allocator.ReserveSessionDescription(&session)
+	assert.True(t, allocator.InUse(111))
+	assert.True(t, allocator.InUse(96))
+	payloadType, err := allocator.Allocate()
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(97), payloadType)
+}
+
+func TestMediaDescription_RemapPayloadTypes(t *testing.T) {
+	md := &MediaDescription{MediaName: MediaName{
+		Media: "video", Port: RangedPort{Value: 9}, Protos: []string{"UDP", "TLS", "RTP", "SAVPF"},
+		Formats: []string{"96", "97", "98", "99", "100"},
+	}}
+	for _, value := range []string{
+		"rtpmap:96 VP8/90000",
+		"rtcp-fb:96 nack",
+		"rtcp-fb:* transport-cc",
+		"rtpmap:97 rtx/90000",
+		"fmtp:97 apt=96; rtx-time=3000",
+		"rtpmap:98 H264/90000",
+		"fmtp:98 profile-level-id=42e01f;packetization-mode=1",
+		"rtpmap:99 rtx/90000",
+		"fmtp:99 apt=98",
+		"rtpmap:100 red/90000",
+		"fmtp:100 96/96",
+		"rid:h send pt=96,98;max-width=1280",
+		"rid:l send",
+	} {
+		key, attributeValue, _ := strings.Cut(value, ":")
+		md.WithValueAttribute(key, attributeValue)
+	}
+
+	assert.NoError(t, md.RemapPayloadTypes(map[uint8]uint8{96: 98, 98: 96, 97: 102, 120: 121}))
+	assert.Equal(t, []string{"98", "102", "96", "99", "100"}, md.MediaName.Formats)
+	var values []string
+	for _, a := range md.Attributes {
+		values = append(values, a.String())
+	}
+	assert.Equal(t, []string{
+		"rtpmap:98 VP8/90000",
+		"rtcp-fb:98 nack",
+		"rtcp-fb:* transport-cc",
+		"rtpmap:102 rtx/90000",
+		"fmtp:102 apt=98; rtx-time=3000",
+		"rtpmap:96 H264/90000",
+		"fmtp:96 profile-level-id=42e01f;packetization-mode=1",
+		"rtpmap:99 rtx/90000",
+		"fmtp:99 apt=96",
+		"rtpmap:100 red/90000",
+		"fmtp:100 98/98",
+		"rid:h send pt=98,96;max-width=1280",
+		"rid:l send",
+	}, values)
+
+	codecs := md.Codecs()
+	assert.Equal(t, "VP8", codecs[0].Name)
+	assert.Equal(t, []string{"nack", "transport-cc"}, codecs[0].RTCPFeedback)
+
+	assert.ErrorIs(t, md.RemapPayloadTypes(map[uint8]uint8{98: 99}), errPayloadTypeCollision)
+	assert.ErrorIs(t, md.RemapPayloadTypes(map[uint8]uint8{98: 128}), errInvalidPayloadType)
+	assert.Equal(t, []string{"98", "102", "96", "99", "100"}, md.MediaName.Formats)
+}
+
+func TestMediaDescription_RemapPayloadTypes_SwapRTXAndRED(t *testing.T) {
+	md := &MediaDescription{MediaName: MediaName{
+		Media: "video", Port: RangedPort{Value: 9}, Protos: []string{"UDP", "TLS", "RTP", "SAVPF"},
+		Formats: []string{"96", "97", "98", "99"},
+	}}
+	for _, value := range []string{
+		"rtpmap:96 VP8/90000",
+		"rtcp-fb:96 nack",
+		"rtcp-fb:* nack pli",
+		"rtpmap:97 rtx/90000",
+		"fmtp:97 apt=96",
+		"rtpmap:98 red/90000",
+		"fmtp:98 96/96",
+		"rtpmap:99 rtx/90000",
+		"fmtp:99 apt=98",
+		"rid:f send pt=96,98;max-width=1280",
+		"rid:q send pt=96",
+	} {
+		key, attributeValue, _ := strings.Cut(value, ":")
+		md.WithValueAttribute(key, attributeValue)
+	}
+
+	// VP8 and the RTX of RED swap, and so do the RTX of VP8 and RED.
+	assert.NoError(t, md.RemapPayloadTypes(map[uint8]uint8{96: 99, 99: 96, 97: 98, 98: 97}))
+	assert.Equal(t, []string{"99", "98", "97", "96"}, md.MediaName.Formats)
+	var values []string
+	for _, a := range md.Attributes {
+		values = append(values, a.String())
+	}
+	assert.Equal(t, []string{
+		"rtpmap:99 VP8/90000",
+		"rtcp-fb:99 nack",
+		"rtcp-fb:* nack pli",
+		"rtpmap:98 rtx/90000",
+		"fmtp:98 apt=99",
+		"rtpmap:97 red/90000",
+		"fmtp:97 99/99",
+		"rtpmap:96 rtx/90000",
+		"fmtp:96 apt=97",
+		"rid:f send pt=99,97;max-width=1280",
+		"rid:q send pt=99",
+	}, values)
+
+	codecs := map[uint8]Codec{}
+	for _, codec := range md.Codecs() {
+		codecs[codec.PayloadType] = codec
+	}
+	assert.Equal(t, "VP8", codecs[99].Name)
+	assert.Equal(t, []string{"nack", "nack pli"}, codecs[99].RTCPFeedback)
+	assert.Equal(t, "red", codecs[97].Name)
+	assert.Equal(t, "99/99", codecs[97].Fmtp)
+	assert.Equal(t, "apt=99", codecs[98].Fmtp)
+	assert.Equal(t, "apt=97", codecs[96].Fmtp)
+}
+
+func TestMediaDescription_RemapPayloadTypes_AttributeOnly(t *testing.T) {
+	newMediaDescription := func() *MediaDescription {
+		md := &MediaDescription{MediaName: MediaName{
+			Media: "video", Port: RangedPort{Value: 9}, Protos: []string{"UDP", "TLS", "RTP", "SAVPF"},
+			Formats: []string{"96", "97"},
+		}}
+
+		return md.WithValueAttribute(attrKeyRtpmap, "96 VP8/90000").
+			WithValueAttribute(attrKeyRtpmap, "97 rtx/90000").
+			WithValueAttribute(attrKeyFmtp, "97 apt= 96 ;rtx-time=3000").
+			WithValueAttribute(attrKeyRtpmap, "98 H264/90000")
+	}
+
+	// 98 is not on the m= line, its mapping is ignored.
+	md := newMediaDescription()
+	assert.NoError(t, md.RemapPayloadTypes(map[uint8]uint8{98: 96}))
+	assert.Equal(t, newMediaDescription(), md)
+
+	// 96 may not take the payload type of the stray rtpmap.
+	assert.ErrorIs(t, md.RemapPayloadTypes(map[uint8]uint8{96: 98}), errPayloadTypeCollision)
+	assert.Equal(t, newMediaDescription(), md)
+
+	// The apt parameter is remapped despite the space around its value.
+	assert.NoError(t, md.RemapPayloadTypes(map[uint8]uint8{96: 100}))
+	value, ok := md.Attribute(attrKeyFmtp)
+	assert.True(t, ok)
+	assert.Equal(t, "97 apt= 100 ;rtx-time=3000", value)
+}
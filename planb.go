@@ -0,0 +1,413 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+)
+
+var errMissingMID = errors.New("sdp: m-section has no mid")
+
+// PlanMappingEntry relates an m-section of Unified Plan to a track of an
+// m-section of Plan B.
+type PlanMappingEntry struct {
+	// PlanBMID is the mid of the Plan B m-section.
+	PlanBMID string
+	// Msid identifies the track, it is zero for an m-section without one.
+	Msid Msid
+	// UnifiedMID is the mid of the Unified Plan m-section.
+	UnifiedMID string
+}
+
+// PlanMapping relates the m-sections of Unified Plan to the tracks of Plan B.
+// Passing the same mapping to every conversion of a session keeps the MIDs
+// and the order of the Unified Plan m-sections stable across renegotiations.
+// The zero value is an empty mapping.
+type PlanMapping struct {
+	// Entries are ordered like the Unified Plan m-sections.
+	Entries []PlanMappingEntry
+}
+
+// UnifiedMID returns the mid of the Unified Plan m-section of a track of a
+// Plan B m-section.
+func (m *PlanMapping) UnifiedMID(planBMID, trackID string) (string, bool) {
+	if i := m.index(planBMID, trackID, nil); i >= 0 {
+		return m.Entries[i].UnifiedMID, true
+	}
+
+	return "", false
+}
+
+// PlanBMID returns the mid of the Plan B m-section of a Unified Plan
+// m-section.
+func (m *PlanMapping) PlanBMID(unifiedMID string) (string, bool) {
+	for _, entry := range m.Entries {
+		if entry.UnifiedMID == unifiedMID {
+			return entry.PlanBMID, true
+		}
+	}
+
+	return "", false
+}
+
+// index returns the entry of a track of a Plan B m-section that is not
+// converted yet, or -1.
+func (m *PlanMapping) index(planBMID, trackID string, converted map[int]*MediaDescription) int {
+	for i, entry := range m.Entries {
+		if _, ok := converted[i]; !ok && entry.PlanBMID == planBMID && entry.Msid.TrackID == trackID {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// add adds an entry for a new Unified Plan m-section. The first m-section of
+// a Plan B m-section keeps its mid, the others get the lowest number that is
+// not a mid yet.
+func (m *PlanMapping) add(planBMID string, msid Msid, reserved []string) int {
+	used := func(mid string) bool {
+		return slices.ContainsFunc(m.Entries, func(entry PlanMappingEntry) bool { return entry.UnifiedMID == mid })
+	}
+
+	mid := planBMID
+	if used(mid) {
+		for n := 0; ; n++ {
+			if mid = strconv.Itoa(n); !used(mid) && !slices.Contains(reserved, mid) {
+				break
+			}
+		}
+	}
+
+	m.Entries = append(m.Entries, PlanMappingEntry{PlanBMID: planBMID, Msid: msid, UnifiedMID: mid})
+
+	return len(m.Entries) - 1
+}
+
+// PlanBToUnifiedPlan converts a Plan B session description, which describes
+// all tracks of a kind in one m-section, to Unified Plan, with an m-section
+// per track. The tracks are identified by the source-level "msid" attributes
+// of their SSRCs.
+//
+// Every track gets a copy of its Plan B m-section with an "a=msid"
+// attribute, the "a=ssrc" attributes of its SSRCs and the "a=ssrc-group"
+// attributes that only contain them. An m-section without tracks is kept as
+// it is. The m-sections are ordered by the mapping, and the m-sections of
+// tracks that were removed since an earlier conversion are rejected. BUNDLE
+// and other groups list the m-sections of the tracks of the Plan B
+// m-sections they contain.
+//
+// The mapping is updated with the new tracks, a nil mapping is treated as an
+// empty one. The session description itself is not modified.
+// https://datatracker.ietf.org/doc/html/draft-uberti-rtcweb-plan-00
+func PlanBToUnifiedPlan(s *SessionDescription, mapping *PlanMapping) (*SessionDescription, error) { //nolint:cyclop
+	if mapping == nil {
+		mapping = &PlanMapping{}
+	}
+
+	planBMIDs := make([]string, 0, len(s.MediaDescriptions))
+	for _, md := range s.MediaDescriptions {
+		mid, ok := md.Attribute(AttrKeyMID)
+		if !ok {
+			return nil, errMissingMID
+		}
+		planBMIDs = append(planBMIDs, mid)
+	}
+
+	converted := map[int]*MediaDescription{}
+	for i, md := range s.MediaDescriptions {
+		planBMID := planBMIDs[i]
+
+		tracks, err := md.mediaStreamTracks()
+		if err != nil {
+			return nil, err
+		}
+		if len(tracks) == 0 {
+			// When all tracks were removed, the m-section of the first
+			// one is kept instead of being rejected.
+			entry := mapping.index(planBMID, "", converted)
+			if entry < 0 {
+				entry = slices.IndexFunc(mapping.Entries, func(entry PlanMappingEntry) bool {
+					return entry.PlanBMID == planBMID
+				})
+			}
+			if entry < 0 {
+				entry = mapping.add(planBMID, Msid{}, planBMIDs)
+			}
+			mapping.Entries[entry].Msid = Msid{}
+			converted[entry] = md.Clone()
+
+			continue
+		}
+
+		ssrcs, err := md.SSRCs()
+		if err != nil {
+			return nil, err
+		}
+		groups, err := md.SSRCGroups()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, track := range tracks {
+			// A new track takes over an m-section without a track, as a
+			// transceiver that starts sending.
+			entry := mapping.index(planBMID, track.ID, converted)
+			if entry < 0 {
+				entry = mapping.index(planBMID, "", converted)
+			}
+			if entry < 0 {
+				entry = mapping.add(planBMID, track.msid, planBMIDs)
+			}
+			mapping.Entries[entry].Msid = track.msid
+			converted[entry] = md.trackSection(track, ssrcs, groups)
+		}
+	}
+
+	unified := s.Clone()
+	unified.preserved = nil
+	unified.MediaDescriptions = nil
+	for i, entry := range mapping.Entries {
+		md, ok := converted[i]
+		if !ok {
+			planB := slices.Index(planBMIDs, entry.PlanBMID)
+			if planB < 0 {
+				continue
+			}
+			md = s.MediaDescriptions[planB].withoutSources()
+			md.MediaName.Port = RangedPort{}
+			md.SetDirection(DirectionInactive)
+		}
+		md.setMID(entry.UnifiedMID)
+		unified.MediaDescriptions = append(unified.MediaDescriptions, md)
+	}
+
+	err := unified.rewriteGroups(func(mid string) []string {
+		if !slices.Contains(planBMIDs, mid) {
+			return []string{mid}
+		}
+
+		var mids []string
+		for i, entry := range mapping.Entries {
+			if _, ok := converted[i]; ok && entry.PlanBMID == mid {
+				mids = append(mids, entry.UnifiedMID)
+			}
+		}
+
+		return mids
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return unified, nil
+}
+
+// UnifiedPlanToPlanB converts a Unified Plan session description to Plan B,
+// merging the m-sections of the tracks of a kind into one m-section. The
+// m-sections are merged as recorded in the mapping, new m-sections are
+// merged into the first m-section of their kind.
+//
+// The merged m-section is a copy of the first m-section that is not
+// rejected, with the mid of the Plan B m-section. It has the "a=ssrc" and
+// "a=ssrc-group" attributes of all merged m-sections, and the "a=msid"
+// attribute of an m-section is added to its SSRCs as source-level "msid"
+// attribute. Its direction sends and receives when any merged m-section
+// does. Tracks without SSRCs cannot be described by Plan B and are dropped.
+//
+// The mapping is updated with the new m-sections, a nil mapping is treated as
+// an empty one. The session description itself is not modified.
+func UnifiedPlanToPlanB(s *SessionDescription, mapping *PlanMapping) (*SessionDescription, error) { //nolint:cyclop
+	if mapping == nil {
+		mapping = &PlanMapping{}
+	}
+
+	type merged struct {
+		md         *MediaDescription
+		active     bool
+		send, recv bool
+	}
+
+	planB := s.Clone()
+	planB.preserved = nil
+	planB.MediaDescriptions = nil
+
+	sections := map[string]*merged{}
+	planBMIDs := map[string]string{}
+	kinds := map[string]string{}
+	for _, md := range s.MediaDescriptions {
+		mid, ok := md.Attribute(AttrKeyMID)
+		if !ok {
+			return nil, errMissingMID
+		}
+
+		msids, err := md.Msids()
+		if err != nil {
+			return nil, err
+		}
+		var msid Msid
+		if len(msids) > 0 {
+			msid = msids[0]
+		}
+
+		entry := slices.IndexFunc(mapping.Entries, func(entry PlanMappingEntry) bool {
+			return entry.UnifiedMID == mid
+		})
+		if entry < 0 {
+			planBMID, ok := kinds[md.MediaName.Media]
+			if !ok {
+				planBMID = mid
+			}
+			entry = len(mapping.Entries)
+			mapping.Entries = append(mapping.Entries, PlanMappingEntry{PlanBMID: planBMID, UnifiedMID: mid})
+		}
+		mapping.Entries[entry].Msid = msid
+		planBMID := mapping.Entries[entry].PlanBMID
+		if _, ok := kinds[md.MediaName.Media]; !ok {
+			kinds[md.MediaName.Media] = planBMID
+		}
+		planBMIDs[mid] = planBMID
+
+		section, ok := sections[planBMID]
+		if !ok {
+			section = &merged{md: md.withoutSources()}
+			section.md.setMID(planBMID)
+			sections[planBMID] = section
+			planB.MediaDescriptions = append(planB.MediaDescriptions, section.md)
+		}
+		if md.IsRejected() {
+			continue
+		}
+
+		if !section.active {
+			*section.md = *md.withoutSources()
+			section.md.setMID(planBMID)
+			section.active = true
+		}
+		direction := md.EffectiveDirection(s)
+		section.send = section.send || direction.sends()
+		section.recv = section.recv || direction.receives()
+
+		if err := section.md.addSources(md, msid, len(msids) > 0); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, section := range sections {
+		direction := directionFrom(section.send, section.recv)
+		if section.active && section.md.EffectiveDirection(planB) != direction {
+			section.md.SetDirection(direction)
+		}
+	}
+
+	err := planB.rewriteGroups(func(mid string) []string {
+		if planBMID, ok := planBMIDs[mid]; ok {
+			return []string{planBMID}
+		}
+
+		return []string{mid}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return planB, nil
+}
+
+// trackSection returns a copy of a Plan B m-section with the sources of one
+// track.
+func (d *MediaDescription) trackSection(track msidTrack, ssrcs []SSRC, groups []SSRCGroup) *MediaDescription {
+	md := d.withoutSources().WithMsid(track.msid)
+	for _, group := range groups {
+		if !slices.ContainsFunc(group.SSRCs, func(ssrc uint32) bool { return !slices.Contains(track.SSRCs, ssrc) }) {
+			md.WithSSRCGroup(group)
+		}
+	}
+	for _, ssrc := range ssrcs {
+		if slices.Contains(track.SSRCs, ssrc.ID) {
+			md.WithSSRC(ssrc)
+		}
+	}
+
+	return md
+}
+
+// addSources adds the "a=ssrc" and "a=ssrc-group" attributes of a Unified
+// Plan m-section to a Plan B m-section.
+func (d *MediaDescription) addSources(md *MediaDescription, msid Msid, hasMsid bool) error {
+	ssrcs, err := md.SSRCs()
+	if err != nil {
+		return err
+	}
+	groups, err := md.SSRCGroups()
+	if err != nil {
+		return err
+	}
+
+	for _, group := range groups {
+		d.WithSSRCGroup(group)
+	}
+	for _, ssrc := range ssrcs {
+		if _, ok := ssrc.Attribute(AttrKeyMsid); !ok && hasMsid {
+			ssrc.Attributes = append(ssrc.Attributes, NewAttribute(AttrKeyMsid, msid.String()))
+		}
+		d.WithSSRC(ssrc)
+	}
+
+	return nil
+}
+
+// withoutSources returns a copy of the media description without the
+// "a=msid", "a=ssrc" and "a=ssrc-group" attributes.
+func (d *MediaDescription) withoutSources() *MediaDescription {
+	md := d.Clone()
+	md.Attributes = slices.DeleteFunc(md.Attributes, func(a Attribute) bool {
+		return a.Key == AttrKeyMsid || a.Key == AttrKeySSRC || a.Key == AttrKeySSRCGroup
+	})
+
+	return md
+}
+
+// setMID replaces the value of the "a=mid" attribute, or adds one.
+func (d *MediaDescription) setMID(mid string) {
+	for i := range d.Attributes {
+		if d.Attributes[i].Key == AttrKeyMID {
+			d.Attributes[i].Value = mid
+
+			return
+		}
+	}
+
+	d.WithValueAttribute(AttrKeyMID, mid)
+}
+
+// rewriteGroups replaces every mid of the "a=group" attributes with the
+// given mids, leaving out duplicates.
+func (s *SessionDescription) rewriteGroups(mids func(mid string) []string) error {
+	for i, a := range s.Attributes {
+		if a.Key != AttrKeyGroup {
+			continue
+		}
+
+		var group Group
+		if err := group.Unmarshal(a.Value); err != nil {
+			return err
+		}
+
+		var rewritten []string
+		for _, mid := range group.MIDs {
+			for _, m := range mids(mid) {
+				if !slices.Contains(rewritten, m) {
+					rewritten = append(rewritten, m)
+				}
+			}
+		}
+		group.MIDs = rewritten
+		s.Attributes[i].Value = group.String()
+	}
+
+	return nil
+}
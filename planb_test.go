@@ -0,0 +1,239 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mustMarshal(t *testing.T, s *SessionDescription) string {
+	t.Helper()
+
+	marsh, err := s.Marshal()
+	assert.NoError(t, err)
+
+	return string(marsh)
+}
+
+func TestPlanBToUnifiedPlan(t *testing.T) {
+	var planB SessionDescription
+	assert.NoError(t, planB.UnmarshalString(planBSDP))
+	original := mustMarshal(t, &planB)
+
+	var mapping PlanMapping
+	unified, err := PlanBToUnifiedPlan(&planB, &mapping)
+	assert.NoError(t, err)
+	assert.Equal(t, original, mustMarshal(t, &planB))
+
+	assert.Equal(t, "v=0\r\n"+
+		"o=- 0 0 IN IP4 127.0.0.1\r\n"+
+		"s=-\r\n"+
+		"t=0 0\r\n"+
+		"a=group:BUNDLE audio video 0\r\n"+
+		"a=msid-semantic: WMS stream\r\n"+
+		"m=audio 9 UDP/TLS/RTP/SAVPF 111\r\n"+
+		"a=mid:audio\r\n"+
+		"a=msid:stream audio0\r\n"+
+		"a=ssrc:1000 cname:user@example\r\n"+
+		"a=ssrc:1000 msid:stream audio0\r\n"+
+		"m=video 9 UDP/TLS/RTP/SAVPF 96 97\r\n"+
+		"a=mid:video\r\n"+
+		"a=msid:stream video0\r\n"+
+		"a=ssrc-group:FID 2000 2001\r\n"+
+		"a=ssrc:2000 cname:user@example\r\n"+
+		"a=ssrc:2000 msid:stream video0\r\n"+
+		"a=ssrc:2001 cname:user@example\r\n"+
+		"a=ssrc:2001 msid:stream video0\r\n"+
+		"m=video 9 UDP/TLS/RTP/SAVPF 96 97\r\n"+
+		"a=mid:0\r\n"+
+		"a=msid:screen video1\r\n"+
+		"a=ssrc-group:FID 3000 3001\r\n"+
+		"a=ssrc:3000 msid:screen video1\r\n"+
+		"a=ssrc:3001 msid:screen video1\r\n",
+		mustMarshal(t, unified))
+
+	assert.Equal(t, []PlanMappingEntry{
+		{PlanBMID: "audio", Msid: Msid{StreamID: "stream", TrackID: "audio0"}, UnifiedMID: "audio"},
+		{PlanBMID: "video", Msid: Msid{StreamID: "stream", TrackID: "video0"}, UnifiedMID: "video"},
+		{PlanBMID: "video", Msid: Msid{StreamID: "screen", TrackID: "video1"}, UnifiedMID: "0"},
+	}, mapping.Entries)
+
+	mid, ok := mapping.UnifiedMID("video", "video1")
+	assert.True(t, ok)
+	assert.Equal(t, "0", mid)
+	_, ok = mapping.UnifiedMID("audio", "video1")
+	assert.False(t, ok)
+	mid, ok = mapping.PlanBMID("0")
+	assert.True(t, ok)
+	assert.Equal(t, "video", mid)
+	_, ok = mapping.PlanBMID("video1")
+	assert.False(t, ok)
+
+	back, err := UnifiedPlanToPlanB(unified, &mapping)
+	assert.NoError(t, err)
+	// The SSRC without a track is dropped.
+	assert.Equal(t, "v=0\r\n"+
+		"o=- 0 0 IN IP4 127.0.0.1\r\n"+
+		"s=-\r\n"+
+		"t=0 0\r\n"+
+		"a=group:BUNDLE audio video\r\n"+
+		"a=msid-semantic: WMS stream\r\n"+
+		"m=audio 9 UDP/TLS/RTP/SAVPF 111\r\n"+
+		"a=mid:audio\r\n"+
+		"a=ssrc:1000 cname:user@example\r\n"+
+		"a=ssrc:1000 msid:stream audio0\r\n"+
+		"m=video 9 UDP/TLS/RTP/SAVPF 96 97\r\n"+
+		"a=mid:video\r\n"+
+		"a=ssrc-group:FID 2000 2001\r\n"+
+		"a=ssrc:2000 cname:user@example\r\n"+
+		"a=ssrc:2000 msid:stream video0\r\n"+
+		"a=ssrc:2001 cname:user@example\r\n"+
+		"a=ssrc:2001 msid:stream video0\r\n"+
+		"a=ssrc-group:FID 3000 3001\r\n"+
+		"a=ssrc:3000 msid:screen video1\r\n"+
+		"a=ssrc:3001 msid:screen video1\r\n",
+		mustMarshal(t, back))
+}
+
+func TestPlanBToUnifiedPlan_Renegotiation(t *testing.T) {
+	var planB SessionDescription
+	assert.NoError(t, planB.UnmarshalString(planBSDP))
+
+	var mapping PlanMapping
+	_, err := PlanBToUnifiedPlan(&planB, &mapping)
+	assert.NoError(t, err)
+
+	// The screen track is replaced by a new track.
+	video := planB.MediaDescriptions[1]
+	for i, a := range video.Attributes {
+		video.Attributes[i].Value = strings.ReplaceAll(a.Value, "screen video1", "stream video2")
+	}
+
+	unified, err := PlanBToUnifiedPlan(&planB, &mapping)
+	assert.NoError(t, err)
+	assert.Len(t, unified.MediaDescriptions, 4)
+
+	rejected := unified.MediaDescriptions[2]
+	assert.True(t, rejected.IsRejected())
+	assert.Equal(t, DirectionInactive, rejected.EffectiveDirection(unified))
+	mid, _ := rejected.Attribute(AttrKeyMID)
+	assert.Equal(t, "0", mid)
+	assert.Empty(t, rejected.Attributes[1:len(rejected.Attributes)-1])
+
+	added := unified.MediaDescriptions[3]
+	mid, _ = added.Attribute(AttrKeyMID)
+	assert.Equal(t, "1", mid)
+	value, _ := added.Attribute(AttrKeyMsid)
+	assert.Equal(t, "stream video2", value)
+
+	value, _ = unified.Attribute(AttrKeyGroup)
+	assert.Equal(t, "BUNDLE audio video 1", value)
+
+	// Without tracks, the video m-section receives on the first m-section.
+	video.Attributes = video.Attributes[:1]
+	video.WithPropertyAttribute(AttrKeyRecvOnly)
+	unified, err = PlanBToUnifiedPlan(&planB, &mapping)
+	assert.NoError(t, err)
+	assert.Len(t, unified.MediaDescriptions, 4)
+	assert.Equal(t, []Attribute{{Key: AttrKeyMID, Value: "video"}, {Key: AttrKeyRecvOnly}},
+		unified.MediaDescriptions[1].Attributes)
+	assert.True(t, unified.MediaDescriptions[3].IsRejected())
+	assert.Equal(t, Msid{}, mapping.Entries[1].Msid)
+
+	// A new track takes over the m-section.
+	video.WithValueAttribute(AttrKeySSRC, "5000 msid:stream video3")
+	unified, err = PlanBToUnifiedPlan(&planB, &mapping)
+	assert.NoError(t, err)
+	assert.Len(t, unified.MediaDescriptions, 4)
+	value, _ = unified.MediaDescriptions[1].Attribute(AttrKeyMsid)
+	assert.Equal(t, "stream video3", value)
+}
+
+func TestUnifiedPlanToPlanB(t *testing.T) {
+	unified := &SessionDescription{}
+	unified.WithGroup(Group{Semantics: SemanticTokenBundle, MIDs: []string{"0", "1", "2", "3"}})
+	unified.WithMedia((&MediaDescription{MediaName: MediaName{Media: "audio", Port: RangedPort{Value: 9}}}).
+		WithValueAttribute(AttrKeyMID, "0").
+		WithPropertyAttribute(AttrKeySendOnly).
+		WithMsid(Msid{StreamID: "s", TrackID: "a"}).
+		WithSSRC(SSRC{ID: 1, Attributes: []Attribute{{Key: "cname", Value: "c"}}}))
+	unified.WithMedia((&MediaDescription{MediaName: MediaName{Media: "video"}}).
+		WithValueAttribute(AttrKeyMID, "1").
+		WithPropertyAttribute(AttrKeyInactive))
+	unified.WithMedia((&MediaDescription{MediaName: MediaName{Media: "video", Port: RangedPort{Value: 9}}}).
+		WithValueAttribute(AttrKeyMID, "2").
+		WithPropertyAttribute(AttrKeyRecvOnly).
+		WithMsid(Msid{StreamID: "s", TrackID: "v"}).
+		WithSSRCGroup(SSRCGroup{Semantics: SemanticTokenFlowIdentification, SSRCs: []uint32{2, 3}}).
+		WithSSRC(SSRC{ID: 2, Attributes: []Attribute{{Key: "cname", Value: "c"}}}).
+		WithSSRC(SSRC{ID: 3, Attributes: []Attribute{{Key: "cname", Value: "c"}, {Key: "msid", Value: "x y"}}}))
+	unified.WithMedia((&MediaDescription{MediaName: MediaName{Media: "video", Port: RangedPort{Value: 9}}}).
+		WithValueAttribute(AttrKeyMID, "3").
+		WithPropertyAttribute(AttrKeySendOnly).
+		WithMsid(Msid{StreamID: "s", TrackID: "w"}).
+		WithSSRC(SSRC{ID: 4, Attributes: []Attribute{{Key: "cname", Value: "c"}}}))
+
+	var mapping PlanMapping
+	planB, err := UnifiedPlanToPlanB(unified, &mapping)
+	assert.NoError(t, err)
+	value, _ := planB.Attribute(AttrKeyGroup)
+	assert.Equal(t, "BUNDLE 0 1", value)
+	assert.Len(t, planB.MediaDescriptions, 2)
+	assert.Equal(t, []Attribute{
+		{Key: "mid", Value: "0"},
+		{Key: "sendonly"},
+		{Key: "ssrc", Value: "1 cname:c"},
+		{Key: "ssrc", Value: "1 msid:s a"},
+	}, planB.MediaDescriptions[0].Attributes)
+	assert.Equal(t, MediaName{Media: "video", Port: RangedPort{Value: 9}}, planB.MediaDescriptions[1].MediaName)
+	assert.Equal(t, []Attribute{
+		{Key: "mid", Value: "1"},
+		{Key: "sendrecv"},
+		{Key: "ssrc-group", Value: "FID 2 3"},
+		{Key: "ssrc", Value: "2 cname:c"},
+		{Key: "ssrc", Value: "2 msid:s v"},
+		{Key: "ssrc", Value: "3 cname:c"},
+		{Key: "ssrc", Value: "3 msid:x y"},
+		{Key: "ssrc", Value: "4 cname:c"},
+		{Key: "ssrc", Value: "4 msid:s w"},
+	}, planB.MediaDescriptions[1].Attributes)
+	assert.Equal(t, []PlanMappingEntry{
+		{PlanBMID: "0", Msid: Msid{StreamID: "s", TrackID: "a"}, UnifiedMID: "0"},
+		{PlanBMID: "1", UnifiedMID: "1"},
+		{PlanBMID: "1", Msid: Msid{StreamID: "s", TrackID: "v"}, UnifiedMID: "2"},
+		{PlanBMID: "1", Msid: Msid{StreamID: "s", TrackID: "w"}, UnifiedMID: "3"},
+	}, mapping.Entries)
+
+	// All m-sections of a kind rejected.
+	unified.MediaDescriptions = unified.MediaDescriptions[:2]
+	planB, err = UnifiedPlanToPlanB(unified, &mapping)
+	assert.NoError(t, err)
+	assert.Len(t, planB.MediaDescriptions, 2)
+	assert.True(t, planB.MediaDescriptions[1].IsRejected())
+	assert.Equal(t, DirectionInactive, planB.MediaDescriptions[1].EffectiveDirection(planB))
+}
+
+func TestPlanConversion_Errors(t *testing.T) {
+	s := (&SessionDescription{}).WithMedia(&MediaDescription{MediaName: MediaName{Media: "audio"}})
+	_, err := PlanBToUnifiedPlan(s, nil)
+	assert.ErrorIs(t, err, errMissingMID)
+	_, err = UnifiedPlanToPlanB(s, nil)
+	assert.ErrorIs(t, err, errMissingMID)
+
+	s.MediaDescriptions[0].WithValueAttribute(AttrKeyMID, "0").WithValueAttribute(AttrKeyMsid, "")
+	_, err = PlanBToUnifiedPlan(s, nil)
+	assert.ErrorIs(t, err, errInvalidMsid)
+	_, err = UnifiedPlanToPlanB(s, nil)
+	assert.ErrorIs(t, err, errInvalidMsid)
+
+	s.MediaDescriptions[0].Attributes = s.MediaDescriptions[0].Attributes[:1]
+	s.WithValueAttribute(AttrKeyGroup, "")
+	_, err = PlanBToUnifiedPlan(s, nil)
+	assert.ErrorIs(t, err, errInvalidGroup)
+	_, err = UnifiedPlanToPlanB(s, nil)
+	assert.ErrorIs(t, err, errInvalidGroup)
+}
@@ -0,0 +1,257 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// preservedText is the original text of a description parsed with
+// UnmarshalOptions.Preserve, split into the lines that Marshal renders.
+type preservedText struct {
+	// canonical holds every line as it was rendered right after parsing,
+	// raw the original text of the same line including the blank lines
+	// before it and its line ending, and order its index in the original
+	// text. The order is -1 for a line that has no original text.
+	canonical []string
+	raw       []string
+	order     []int
+
+	// trailer is the text after the last line.
+	trailer string
+
+	// lineEnding is used for the lines that are rendered.
+	lineEnding string
+}
+
+// preservedKey identifies a line by its section, its type and its index among
+// the lines of that type in the section. Repeat times are counted with the
+// timing lines they belong to.
+type preservedKey struct {
+	section int
+	group   byte
+	index   int
+}
+
+type preservedKeys struct {
+	section int
+	counts  map[preservedKey]int
+}
+
+func (k *preservedKeys) next(line string) preservedKey {
+	group := line[0]
+	switch group {
+	case 'm':
+		k.section++
+	case 'r':
+		group = 't'
+	}
+
+	key := preservedKey{section: k.section, group: group}
+	index := k.counts[key]
+	k.counts[key]++
+	key.index = index
+
+	return key
+}
+
+func newPreservedText(s *SessionDescription, value string, skipped map[int]bool) *preservedText {
+	canonical, err := canonicalLines(s)
+	if err != nil {
+		return nil
+	}
+
+	preserved := &preservedText{
+		canonical:  canonical,
+		raw:        make([]string, len(canonical)),
+		order:      make([]int, len(canonical)),
+		lineEnding: "\r\n",
+	}
+
+	originals := map[preservedKey]int{}
+	var raw []string
+	keys := preservedKeys{counts: map[preservedKey]int{}}
+	prefix, pos := "", 0
+	for pos < len(value) {
+		end := strings.IndexByte(value[pos:], '\n')
+		if end < 0 {
+			break
+		}
+		line := value[pos : pos+end+1]
+		lineStart := pos + len(line) - len(strings.TrimLeft(line, "\r"))
+		pos += len(line)
+
+		content := strings.TrimRight(line, "\r\n")
+		switch {
+		case strings.Trim(content, "\r") == "":
+			prefix += line
+		case skipped[lineStart]:
+		default:
+			if len(raw) == 0 && !strings.HasSuffix(line, "\r\n") {
+				preserved.lineEnding = "\n"
+			}
+			originals[keys.next(strings.TrimLeft(content, "\r"))] = len(raw)
+			raw = append(raw, prefix+line)
+			prefix = ""
+		}
+	}
+	preserved.trailer = prefix + value[pos:]
+
+	keys = preservedKeys{counts: map[preservedKey]int{}}
+	for i, line := range canonical {
+		index, ok := originals[keys.next(line)]
+		if !ok {
+			preserved.order[i] = -1
+
+			continue
+		}
+		preserved.raw[i] = raw[index]
+		preserved.order[i] = index
+	}
+
+	return preserved
+}
+
+type preservedLine struct {
+	anchor, sub int
+	text        string
+}
+
+// marshal renders the description, reusing the original text of every line
+// that is unchanged since parsing. Unchanged lines keep their original order,
+// and rendered lines follow the line they follow in the canonical order.
+func (p *preservedText) marshal(s *SessionDescription) ([]byte, error) {
+	output, size, err := p.lines(s)
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortStableFunc(output, func(a, b preservedLine) int {
+		if c := cmp.Compare(a.anchor, b.anchor); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(a.sub, b.sub)
+	})
+
+	marsh := make([]byte, 0, size)
+	for _, line := range output {
+		marsh = append(marsh, line.text...)
+	}
+
+	return append(marsh, p.trailer...), nil
+}
+
+// marshalSize returns the size of the description rendered by marshal.
+func (p *preservedText) marshalSize(s *SessionDescription) (int, error) {
+	_, size, err := p.lines(s)
+
+	return size, err
+}
+
+// lines returns the unsorted lines rendered by marshal and their total size,
+// trailer included.
+func (p *preservedText) lines(s *SessionDescription) ([]preservedLine, int, error) {
+	current, err := canonicalLines(s)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	matches := matchLines(p.canonical, current)
+	output := make([]preservedLine, 0, len(current))
+	size := len(p.trailer)
+	anchor, sub := -1, 0
+	for i, line := range current {
+		if match := matches[i]; match >= 0 && p.order[match] >= 0 {
+			anchor, sub = p.order[match], 0
+			output = append(output, preservedLine{anchor: anchor, text: p.raw[match]})
+		} else {
+			sub++
+			output = append(output, preservedLine{anchor: anchor, sub: sub, text: line + p.lineEnding})
+		}
+		size += len(output[len(output)-1].text)
+	}
+
+	return output, size, nil
+}
+
+// canonicalLines renders the description without its preserved text and
+// returns its lines without line endings.
+func canonicalLines(s *SessionDescription) ([]string, error) {
+	canonical := *s
+	canonical.preserved = nil
+	marsh, err := canonical.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	text := strings.TrimSuffix(string(marsh), "\r\n")
+	if text == "" {
+		return nil, nil
+	}
+
+	return strings.Split(text, "\r\n"), nil
+}
+
+// matchLines aligns two versions of the same lines with a longest common
+// subsequence, and returns for every line of b the index of the matching line
+// of a, or -1 if the line is not part of a.
+func matchLines(a, b []string) []int {
+	matches := make([]int, len(b))
+	for i := range matches {
+		matches[i] = -1
+	}
+
+	// Edits are usually few, so equal lines at both ends are matched
+	// directly to keep the table small.
+	start := 0
+	for start < len(a) && start < len(b) && a[start] == b[start] {
+		matches[start] = start
+		start++
+	}
+	endA, endB := len(a), len(b)
+	for endA > start && endB > start && a[endA-1] == b[endB-1] {
+		endA--
+		endB--
+		matches[endB] = endA
+	}
+
+	rows, cols := endA-start, endB-start
+	if rows == 0 || cols == 0 {
+		return matches
+	}
+
+	// lengths[i][j] is the length of the longest common subsequence of
+	// a[start+i:endA] and b[start+j:endB].
+	lengths := make([][]int, rows+1)
+	for i := range lengths {
+		lengths[i] = make([]int, cols+1)
+	}
+	for i := rows - 1; i >= 0; i-- {
+		for j := cols - 1; j >= 0; j-- {
+			if a[start+i] == b[start+j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+
+	for i, j := 0, 0; i < rows && j < cols; {
+		switch {
+		case a[start+i] == b[start+j]:
+			matches[start+j] = start + i
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+
+	return matches
+}
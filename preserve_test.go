@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const preserveSDP = "v=0\n" +
+	"o=- 4215775240449105457 2 IN IP4 127.0.0.1\n" +
+	"s=-\n" +
+	"t=0 0\n" +
+	"a=group:BUNDLE 0\n" +
+	"\n" +
+	"m=audio 9 UDP/TLS/RTP/SAVPF 111 0\n" +
+	"a=mid:0\n" +
+	"c=IN IP4 0.0.0.0\n" +
+	"a=rtpmap:111 opus/48000/2\n" +
+	"a=fmtp:111 minptime=10;useinbandfec=1\r\n" +
+	"a=sendrecv\n"
+
+func TestUnmarshalStringWithOptions_Preserve(t *testing.T) {
+	var sd SessionDescription
+	_, err := sd.UnmarshalStringWithOptions(preserveSDP, UnmarshalOptions{Preserve: true})
+	assert.NoError(t, err)
+
+	actual, err := sd.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, preserveSDP, string(actual))
+	assert.Equal(t, len(actual), sd.MarshalSize())
+
+	var canonical SessionDescription
+	assert.NoError(t, canonical.UnmarshalString(preserveSDP))
+	assert.Equal(t, canonical.MediaDescriptions, sd.MediaDescriptions)
+
+	expected, err := canonical.Marshal()
+	assert.NoError(t, err)
+	assert.NotEqual(t, preserveSDP, string(expected))
+}
+
+func TestUnmarshalStringWithOptions_Preserve_Modified(t *testing.T) {
+	var sd SessionDescription
+	_, err := sd.UnmarshalStringWithOptions(preserveSDP, UnmarshalOptions{Preserve: true})
+	assert.NoError(t, err)
+
+	sd.Origin.SessionVersion = 3
+	media := sd.MediaDescriptions[0]
+	media.Attributes = media.Attributes[:len(media.Attributes)-1]
+	media.WithPropertyAttribute(AttrKeyRecvOnly)
+	media.WithValueAttribute("ptime", "20")
+	sd.WithPropertyAttribute("ice-lite")
+
+	actual, err := sd.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, "v=0\n"+
+		"o=- 4215775240449105457 3 IN IP4 127.0.0.1\n"+
+		"s=-\n"+
+		"t=0 0\n"+
+		"a=group:BUNDLE 0\n"+
+		"a=ice-lite\n"+
+		"\n"+
+		"m=audio 9 UDP/TLS/RTP/SAVPF 111 0\n"+
+		"a=mid:0\n"+
+		"c=IN IP4 0.0.0.0\n"+
+		"a=rtpmap:111 opus/48000/2\n"+
+		"a=fmtp:111 minptime=10;useinbandfec=1\r\n"+
+		"a=recvonly\n"+
+		"a=ptime:20\n", string(actual))
+	assert.Equal(t, len(actual), sd.MarshalSize())
+}
+
+func TestUnmarshalStringWithOptions_Preserve_Lenient(t *testing.T) {
+	sdp := "v=0\r\n" +
+		"o=- 123 1 IN IP4 127.0.0.1\r\n" +
+		"s=-\r\n" +
+		"t=0 0\r\n" +
+		"x=unknown\r\n" +
+		"a=ice-lite\r\n" +
+		"m=audio 9 RTP/AVP 0\r\n" +
+		"b=AS:fast\r\n" +
+		"a=sendrecv \r\n"
+
+	var sd SessionDescription
+	warnings, err := sd.UnmarshalStringWithOptions(sdp, UnmarshalOptions{Lenient: true, Preserve: true})
+	assert.NoError(t, err)
+	assert.Len(t, warnings, 2)
+
+	actual, err := sd.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, "v=0\r\n"+
+		"o=- 123 1 IN IP4 127.0.0.1\r\n"+
+		"s=-\r\n"+
+		"t=0 0\r\n"+
+		"a=ice-lite\r\n"+
+		"m=audio 9 RTP/AVP 0\r\n"+
+		"a=sendrecv \r\n", string(actual))
+	assert.Equal(t, len(actual), sd.MarshalSize())
+}
+
+func TestMatchLines(t *testing.T) {
+	assert.Equal(t, []int{0, -1, 2, 3}, matchLines(
+		[]string{"a", "b", "c", "d"},
+		[]string{"a", "x", "c", "d"},
+	))
+	assert.Equal(t, []int{1, -1, -1}, matchLines(
+		[]string{"b", "a"},
+		[]string{"a", "b", "c"},
+	))
+	assert.Equal(t, []int{-1, -1}, matchLines(nil, []string{"a", "b"}))
+}
@@ -0,0 +1,308 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"net/netip"
+	"strings"
+)
+
+// RedactPolicy selects how Redact treats a value.
+type RedactPolicy int
+
+const (
+	// RedactDefault applies the default policy of the value.
+	RedactDefault RedactPolicy = iota
+
+	// RedactKeep keeps the value unchanged.
+	RedactKeep
+
+	// RedactMask replaces the secret part of the value with the mask.
+	RedactMask
+
+	// RedactAnonymize replaces the IP addresses in the value with
+	// pseudonyms derived from a keyed hash of the address. The same address
+	// always gets the same pseudonym from a Redactor, see RedactOptions.Key.
+	RedactAnonymize
+
+	// RedactDrop removes the value.
+	RedactDrop
+)
+
+const (
+	attrKeyICEUfrag         = "ice-ufrag"
+	attrKeyICEPwd           = "ice-pwd"
+	attrKeyCrypto           = "crypto"
+	attrKeyKeyMgmt          = "key-mgmt"
+	attrKeyRTCP             = "rtcp"
+	attrKeyRemoteCandidates = "remote-candidates"
+
+	defaultRedactMask = "REDACTED"
+)
+
+// RedactOptions configures a Redactor.
+type RedactOptions struct {
+	// Attributes selects the policy per attribute key. Keys that are not
+	// listed, or listed with RedactDefault, use the default policy:
+	//
+	//   - "ice-pwd" and "key-mgmt" are masked
+	//   - the inline keys of "crypto" are masked
+	//   - "candidate", "remote-candidates" and "rtcp" are anonymized
+	//   - every other attribute is kept
+	Attributes map[string]RedactPolicy
+
+	// Addresses is the policy for the addresses of the o= and c= lines,
+	// which are anonymized by default. RedactDrop masks them, since the
+	// lines are required.
+	Addresses RedactPolicy
+
+	// EncryptionKeys is the policy for the k= lines, which are masked by
+	// default. Only the key is masked, the method is kept.
+	EncryptionKeys RedactPolicy
+
+	// DropCandidates removes the "candidate" attributes unless a policy is
+	// set for them in Attributes.
+	DropCandidates bool
+
+	// Mask replaces the masked values, "REDACTED" by default.
+	Mask string
+
+	// Key is the key of the hash the address pseudonyms are derived from.
+	// Redactors with the same key give the same pseudonyms, which correlates
+	// descriptions redacted by different processes. A random key is used
+	// when it is empty.
+	Key []byte
+}
+
+// Redactor removes secrets and personal data from session descriptions, so
+// that they can be logged. It is safe for concurrent use, and anonymizes the
+// addresses of all the descriptions it redacts consistently. It keeps no
+// state besides its options, however many addresses it anonymizes.
+type Redactor struct {
+	options RedactOptions
+}
+
+// NewRedactor creates a Redactor with the given options.
+func NewRedactor(options RedactOptions) *Redactor {
+	if options.Mask == "" {
+		options.Mask = defaultRedactMask
+	}
+	if len(options.Key) == 0 {
+		options.Key = make([]byte, sha256.Size)
+		_, _ = rand.Read(options.Key)
+	}
+
+	return &Redactor{options: options}
+}
+
+// Redact returns a redacted copy of the session description, see
+// RedactOptions. Addresses are anonymized consistently within the copy.
+func Redact(s *SessionDescription, options RedactOptions) *SessionDescription {
+	return NewRedactor(options).Redact(s)
+}
+
+// Redact returns a redacted copy of the session description. The session
+// description itself is not modified.
+func (r *Redactor) Redact(s *SessionDescription) *SessionDescription {
+	redacted := s.Clone()
+	redacted.Origin.UnicastAddress = r.redactAddress(redacted.Origin.UnicastAddress)
+	r.redactConnectionInformation(redacted.ConnectionInformation)
+	redacted.EncryptionKey = r.redactEncryptionKey(redacted.EncryptionKey)
+	redacted.Attributes = r.redactAttributes(redacted.Attributes)
+
+	for _, md := range redacted.MediaDescriptions {
+		r.redactConnectionInformation(md.ConnectionInformation)
+		md.EncryptionKey = r.redactEncryptionKey(md.EncryptionKey)
+		md.Attributes = r.redactAttributes(md.Attributes)
+	}
+
+	return redacted
+}
+
+func (r *Redactor) redactAddress(address string) string {
+	switch r.options.Addresses {
+	case RedactKeep:
+		return address
+	case RedactMask, RedactDrop:
+		// Keep the multicast TTL and number of addresses.
+		if _, suffix, found := strings.Cut(address, "/"); found {
+			return r.options.Mask + "/" + suffix
+		}
+
+		return r.options.Mask
+	default:
+		return r.anonymize(address)
+	}
+}
+
+func (r *Redactor) redactConnectionInformation(c *ConnectionInformation) {
+	if c != nil && c.Address != nil {
+		c.Address.Address = r.redactAddress(c.Address.Address)
+	}
+}
+
+func (r *Redactor) redactEncryptionKey(key *EncryptionKey) *EncryptionKey {
+	if key == nil {
+		return nil
+	}
+
+	var value string
+	switch r.options.EncryptionKeys {
+	case RedactKeep:
+		return key
+	case RedactDrop:
+		return nil
+	case RedactAnonymize:
+		value = r.anonymizeTokens(string(*key))
+	default:
+		// k=<method>:<encryption key>, the "prompt" method has no key.
+		method, _, found := strings.Cut(string(*key), ":")
+		if !found {
+			return key
+		}
+		value = method + ":" + r.options.Mask
+	}
+
+	redacted := EncryptionKey(value)
+
+	return &redacted
+}
+
+func (r *Redactor) attributePolicy(key string) RedactPolicy {
+	if policy := r.options.Attributes[key]; policy != RedactDefault {
+		return policy
+	}
+
+	switch key {
+	case attrKeyICEPwd, attrKeyCrypto, attrKeyKeyMgmt:
+		return RedactMask
+	case AttrKeyCandidate:
+		if r.options.DropCandidates {
+			return RedactDrop
+		}
+
+		return RedactAnonymize
+	case attrKeyRemoteCandidates, attrKeyRTCP:
+		return RedactAnonymize
+	default:
+		return RedactKeep
+	}
+}
+
+func (r *Redactor) redactAttributes(attributes []Attribute) []Attribute {
+	redacted := attributes[:0]
+	for _, a := range attributes {
+		switch r.attributePolicy(a.Key) {
+		case RedactDrop:
+			continue
+		case RedactMask:
+			a.Value = r.mask(a)
+		case RedactAnonymize:
+			a.Value = r.anonymizeTokens(a.Value)
+		default:
+		}
+		redacted = append(redacted, a)
+	}
+
+	if len(redacted) == 0 {
+		return nil
+	}
+
+	return redacted
+}
+
+// mask masks the value of an attribute. Only the key-salt of the inline key
+// parameters of "a=crypto" is masked, which keeps the crypto suite, lifetime
+// and MKI readable.
+// https://datatracker.ietf.org/doc/html/rfc4568#section-9.1
+func (r *Redactor) mask(a Attribute) string {
+	if a.Value == "" {
+		return ""
+	}
+	if a.Key != attrKeyCrypto {
+		return r.options.Mask
+	}
+
+	const inline = "inline:"
+	var b strings.Builder
+	value := a.Value
+	for {
+		i := strings.Index(value, inline)
+		if i < 0 {
+			b.WriteString(value)
+
+			return b.String()
+		}
+		b.WriteString(value[:i+len(inline)])
+		b.WriteString(r.options.Mask)
+
+		value = value[i+len(inline):]
+		end := strings.IndexAny(value, "|; ")
+		if end < 0 {
+			return b.String()
+		}
+		value = value[end:]
+	}
+}
+
+// anonymizeTokens replaces the IP addresses among the space separated tokens
+// of a value.
+func (r *Redactor) anonymizeTokens(value string) string {
+	tokens := strings.Split(value, " ")
+	for i, token := range tokens {
+		tokens[i] = r.anonymize(token)
+	}
+
+	return strings.Join(tokens, " ")
+}
+
+// anonymize replaces an IP address, optionally followed by a "/" suffix such
+// as a multicast TTL, with its pseudonym. Other values, unspecified and
+// loopback addresses are returned unchanged.
+func (r *Redactor) anonymize(value string) string {
+	address, suffix, found := strings.Cut(value, "/")
+	addr, err := netip.ParseAddr(address)
+	if err != nil || addr.IsUnspecified() || addr.IsLoopback() {
+		return value
+	}
+
+	pseudonym := r.pseudonym(addr)
+	if found {
+		return pseudonym.String() + "/" + suffix
+	}
+
+	return pseudonym.String()
+}
+
+// pseudonym derives the pseudonym of an address from its HMAC-SHA256. IPv4
+// pseudonyms are taken from the 198.18.0.0/15 benchmarking block, IPv6
+// pseudonyms from the 2001:db8::/32 documentation prefix. The IPv4 block
+// has only 2^17-1 host addresses, so two IPv4 addresses may share a
+// pseudonym, while IPv6 collisions are negligible.
+// https://datatracker.ietf.org/doc/html/rfc2544#appendix-C.2.2
+// https://datatracker.ietf.org/doc/html/rfc3849
+func (r *Redactor) pseudonym(addr netip.Addr) netip.Addr {
+	mac := hmac.New(sha256.New, r.options.Key)
+	mac.Write(addr.AsSlice())
+	sum := mac.Sum(nil)
+
+	if addr.Is4() {
+		const block = 1 << 17
+		host := binary.BigEndian.Uint32(sum)%(block-1) + 1
+
+		var pseudonym [4]byte
+		binary.BigEndian.PutUint32(pseudonym[:], (198<<24|18<<16)+host)
+
+		return netip.AddrFrom4(pseudonym)
+	}
+
+	pseudonym := [16]byte{0x20, 0x01, 0x0d, 0xb8}
+	copy(pseudonym[4:], sum)
+
+	return netip.AddrFrom16(pseudonym)
+}
@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const redactSDP = "v=0\r\n" +
+	"o=- 4215775240449105457 2 IN IP4 203.0.113.7\r\n" +
+	"s=-\r\n" +
+	"c=IN IP4 224.2.1.1/127\r\n" +
+	"t=0 0\r\n" +
+	"k=clear:secret\r\n" +
+	"a=ice-ufrag:Fk7L\r\n" +
+	"a=ice-pwd:kN3dH2ZzJ4ptHQdp0BbGNmXn\r\n" +
+	"m=audio 9 RTP/SAVP 0\r\n" +
+	"c=IN IP6 2001:db8:85a3::8a2e:370:7334\r\n" +
+	"k=prompt\r\n" +
+	"a=rtcp:9 IN IP4 203.0.113.7\r\n" +
+	"a=crypto:1 AES_CM_128_HMAC_SHA1_80 inline:PS1uQCVeeCFCanVmcjkpPywjNWhcYD0mXXtxaVBR|2^20|1:32\r\n" +
+	"a=candidate:1 1 udp 2122260223 192.168.1.10 54400 typ host\r\n" +
+	"a=candidate:2 1 udp 1686052607 203.0.113.7 54400 typ srflx raddr 192.168.1.10 rport 54400\r\n" +
+	"a=candidate:3 1 udp 2122262783 fe80::1 54401 typ host\r\n" +
+	"a=end-of-candidates\r\n"
+
+func TestRedact(t *testing.T) {
+	var sd SessionDescription
+	assert.NoError(t, sd.UnmarshalString(redactSDP))
+
+	redacted, err := Redact(&sd, RedactOptions{Key: []byte("key")}).Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, "v=0\r\n"+
+		"o=- 4215775240449105457 2 IN IP4 198.18.195.110\r\n"+
+		"s=-\r\n"+
+		"c=IN IP4 198.19.125.149/127\r\n"+
+		"t=0 0\r\n"+
+		"k=clear:REDACTED\r\n"+
+		"a=ice-ufrag:Fk7L\r\n"+
+		"a=ice-pwd:REDACTED\r\n"+
+		"m=audio 9 RTP/SAVP 0\r\n"+
+		"c=IN IP6 2001:db8:5ddc:88dc:275a:63c2:1b45:c022\r\n"+
+		"k=prompt\r\n"+
+		"a=rtcp:9 IN IP4 198.18.195.110\r\n"+
+		"a=crypto:1 AES_CM_128_HMAC_SHA1_80 inline:REDACTED|2^20|1:32\r\n"+
+		"a=candidate:1 1 udp 2122260223 198.19.110.56 54400 typ host\r\n"+
+		"a=candidate:2 1 udp 1686052607 198.18.195.110 54400 typ srflx raddr 198.19.110.56 rport 54400\r\n"+
+		"a=candidate:3 1 udp 2122262783 2001:db8:bdfc:70e:4782:ff35:2c16:bfd4 54401 typ host\r\n"+
+		"a=end-of-candidates\r\n", string(redacted))
+
+	// The original is not modified.
+	original, err := sd.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, redactSDP, string(original))
+}
+
+func TestRedact_Policies(t *testing.T) {
+	var sd SessionDescription
+	assert.NoError(t, sd.UnmarshalString(redactSDP))
+
+	redacted, err := Redact(&sd, RedactOptions{
+		Attributes: map[string]RedactPolicy{
+			"ice-ufrag":         RedactMask,
+			"ice-pwd":           RedactDrop,
+			"crypto":            RedactKeep,
+			"rtcp":              RedactDrop,
+			AttrKeyCandidate:    RedactDefault,
+			"end-of-candidates": RedactDrop,
+		},
+		Addresses:      RedactMask,
+		EncryptionKeys: RedactDrop,
+		DropCandidates: true,
+		Mask:           "***",
+	}).Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, "v=0\r\n"+
+		"o=- 4215775240449105457 2 IN IP4 ***\r\n"+
+		"s=-\r\n"+
+		"c=IN IP4 ***/127\r\n"+
+		"t=0 0\r\n"+
+		"a=ice-ufrag:***\r\n"+
+		"m=audio 9 RTP/SAVP 0\r\n"+
+		"c=IN IP6 ***\r\n"+
+		"a=crypto:1 AES_CM_128_HMAC_SHA1_80 inline:PS1uQCVeeCFCanVmcjkpPywjNWhcYD0mXXtxaVBR|2^20|1:32\r\n",
+		string(redacted))
+}
+
+func TestRedactor_Consistent(t *testing.T) {
+	first := (&SessionDescription{}).WithValueAttribute(AttrKeyCandidate,
+		"1 1 udp 2122260223 192.168.1.10 54400 typ host")
+	second := (&SessionDescription{}).WithValueAttribute(AttrKeyCandidate,
+		"1 1 udp 2122260223 192.168.1.11 54400 typ host").WithValueAttribute(AttrKeyCandidate,
+		"2 1 udp 2122260223 192.168.1.10 54400 typ host")
+
+	redactor := NewRedactor(RedactOptions{})
+	pseudonym := redactor.Redact(first).Attributes[0].Value
+	assert.NotContains(t, pseudonym, "192.168.1.10")
+
+	redacted := redactor.Redact(second)
+	assert.NotEqual(t, "1"+pseudonym[1:], redacted.Attributes[0].Value)
+	assert.Equal(t, "2"+pseudonym[1:], redacted.Attributes[1].Value)
+
+	// Redactors with the same key give the same pseudonyms.
+	key := []byte("key")
+	assert.Equal(t, NewRedactor(RedactOptions{Key: key}).Redact(first).Attributes,
+		NewRedactor(RedactOptions{Key: key}).Redact(first).Attributes)
+	assert.NotEqual(t, NewRedactor(RedactOptions{Key: key}).Redact(first).Attributes,
+		NewRedactor(RedactOptions{Key: []byte("other key")}).Redact(first).Attributes)
+}
+
+func TestRedactor_Pseudonym(t *testing.T) {
+	redactor := NewRedactor(RedactOptions{})
+	benchmarking := netip.MustParsePrefix("198.18.0.0/15")
+	documentation := netip.MustParsePrefix("2001:db8::/32")
+	for i := range 1 << 10 {
+		addr := netip.AddrFrom4([4]byte{10, 0, byte(i >> 8), byte(i)})
+		pseudonym := netip.MustParseAddr(redactor.anonymize(addr.String()))
+		assert.True(t, benchmarking.Contains(pseudonym), pseudonym)
+		assert.NotEqual(t, benchmarking.Addr(), pseudonym)
+
+		addr = netip.AddrFrom16([16]byte{0xfe, 0x80, 14: byte(i >> 8), 15: byte(i)})
+		assert.True(t, documentation.Contains(netip.MustParseAddr(redactor.anonymize(addr.String()))))
+	}
+
+	assert.Equal(t, "0.0.0.0", redactor.anonymize("0.0.0.0"))
+	assert.Equal(t, "::1", redactor.anonymize("::1"))
+	assert.Equal(t, "host.local", redactor.anonymize("host.local"))
+}
@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"errors"
+	"fmt"
+)
+
+// SDPType describes the role of a session description in the offer/answer
+// exchange.
+// https://www.w3.org/TR/webrtc/#dom-rtcsdptype
+type SDPType int
+
+const (
+	// SDPTypeOffer is a session description sent as an offer.
+	SDPTypeOffer SDPType = iota + 1
+	// SDPTypePranswer is a provisional answer, which may be followed by
+	// further provisional answers and a final answer.
+	SDPTypePranswer
+	// SDPTypeAnswer is the final answer to an offer.
+	SDPTypeAnswer
+	// SDPTypeRollback cancels the current offer/answer exchange.
+	SDPTypeRollback
+)
+
+const (
+	sdpTypeOfferStr    = "offer"
+	sdpTypePranswerStr = "pranswer"
+	sdpTypeAnswerStr   = "answer"
+	sdpTypeRollbackStr = "rollback"
+	sdpTypeUnknownStr  = ""
+)
+
+var errSDPTypeString = errors.New("sdp: invalid SDP type")
+
+// NewSDPType creates an SDPType from its string form.
+func NewSDPType(raw string) (SDPType, error) {
+	switch raw {
+	case sdpTypeOfferStr:
+		return SDPTypeOffer, nil
+	case sdpTypePranswerStr:
+		return SDPTypePranswer, nil
+	case sdpTypeAnswerStr:
+		return SDPTypeAnswer, nil
+	case sdpTypeRollbackStr:
+		return SDPTypeRollback, nil
+	default:
+		return SDPType(unknown), fmt.Errorf("%w: %q", errSDPTypeString, raw)
+	}
+}
+
+func (t SDPType) String() string {
+	switch t {
+	case SDPTypeOffer:
+		return sdpTypeOfferStr
+	case SDPTypePranswer:
+		return sdpTypePranswerStr
+	case SDPTypeAnswer:
+		return sdpTypeAnswerStr
+	case SDPTypeRollback:
+		return sdpTypeRollbackStr
+	default:
+		return sdpTypeUnknownStr
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t SDPType) MarshalText() ([]byte, error) {
+	if t.String() == sdpTypeUnknownStr {
+		return nil, fmt.Errorf("%w: %d", errSDPTypeString, int(t))
+	}
+
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *SDPType) UnmarshalText(text []byte) error {
+	sdpType, err := NewSDPType(string(text))
+	if err != nil {
+		return err
+	}
+	*t = sdpType
+
+	return nil
+}
@@ -0,0 +1,37 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSDPType(t *testing.T) {
+	for _, sdpType := range []SDPType{SDPTypeOffer, SDPTypePranswer, SDPTypeAnswer, SDPTypeRollback} {
+		parsed, err := NewSDPType(sdpType.String())
+		assert.NoError(t, err)
+		assert.Equal(t, sdpType, parsed)
+	}
+
+	_, err := NewSDPType("Offer")
+	assert.ErrorIs(t, err, errSDPTypeString)
+	assert.Empty(t, SDPType(unknown).String())
+}
+
+func TestSDPType_Text(t *testing.T) {
+	text, err := SDPTypePranswer.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "pranswer", string(text))
+
+	_, err = SDPType(unknown).MarshalText()
+	assert.ErrorIs(t, err, errSDPTypeString)
+
+	var sdpType SDPType
+	assert.NoError(t, sdpType.UnmarshalText([]byte("rollback")))
+	assert.Equal(t, SDPTypeRollback, sdpType)
+	assert.ErrorIs(t, sdpType.UnmarshalText([]byte("")), errSDPTypeString)
+	assert.Equal(t, SDPTypeRollback, sdpType)
+}
@@ -67,6 +67,13 @@ type SessionDescription struct {
 
 	// https://tools.ietf.org/html/rfc4566#section-5.14
 	MediaDescriptions []*MediaDescription
+
+	// preserved holds the original text when the description was parsed
+	// with UnmarshalOptions.Preserve.
+	preserved *preservedText
+
+	// profile is the specification the description was parsed against.
+	profile SpecProfile
 }
 
 // Attribute returns the value of an attribute and if it exists.
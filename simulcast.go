@@ -0,0 +1,583 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Directions of a RID and of the stream lists of a Simulcast.
+const (
+	RIDDirectionSend = "send"
+	RIDDirectionRecv = "recv"
+)
+
+const (
+	ridParamPayloadTypes = "pt"
+	ridParamMaxWidth     = "max-width"
+	ridParamMaxHeight    = "max-height"
+	ridParamMaxFPS       = "max-fps"
+	ridParamMaxFS        = "max-fs"
+	ridParamMaxBR        = "max-br"
+	ridParamMaxPPS       = "max-pps"
+	ridParamMaxBPP       = "max-bpp"
+	ridParamDepend       = "depend"
+)
+
+var (
+	errInvalidRID               = errors.New("sdp: invalid rid")
+	errInvalidSimulcast         = errors.New("sdp: invalid simulcast")
+	errSimulcastUnknownRID      = errors.New("sdp: simulcast references undeclared rid")
+	errSimulcastRIDDirection    = errors.New("sdp: simulcast rid has the wrong direction")
+	errRIDDuplicate             = errors.New("sdp: rid is declared more than once")
+	errRIDUnknownPayloadType    = errors.New("sdp: rid references payload type not in m= line")
+	errRIDUnknownDependency     = errors.New("sdp: rid depends on undeclared rid")
+	errSimulcastDuplicateStream = errors.New("sdp: rid is part of more than one simulcast stream")
+)
+
+// RIDParam is a restriction of a RID that is not otherwise modelled by RID.
+type RIDParam struct {
+	Key   string
+	Value string
+}
+
+// RID represents the value of an "a=rid" attribute, which restricts an RTP
+// stream identified by its RID.
+//
+//	rid-syntax = %s"a=rid:" rid-id SP rid-dir
+//	             [ rid-pt-param-list / rid-param-list ]
+//
+// https://datatracker.ietf.org/doc/html/rfc8851#section-10
+type RID struct {
+	ID string
+	// Direction is RIDDirectionSend or RIDDirectionRecv.
+	Direction string
+
+	// PayloadTypes restricts the stream to these payload types, any payload
+	// type of the m= line is allowed when empty.
+	PayloadTypes []uint8
+
+	// The restrictions of the stream, nil when not present. A restriction
+	// set to zero is kept.
+	MaxWidth  *uint32
+	MaxHeight *uint32
+	MaxFPS    *float64
+	MaxFS     *uint32
+	MaxBR     *uint32
+	MaxPPS    *uint32
+	MaxBPP    *float64
+
+	// Depend lists the RIDs the stream depends on.
+	Depend []string
+
+	// Params holds every other restriction in the order it was read.
+	Params []RIDParam
+}
+
+// Unmarshal creates a RID from a string. The "a=" and "rid:" prefixes are
+// optional.
+func (r *RID) Unmarshal(raw string) error { //nolint:cyclop
+	raw = strings.TrimPrefix(strings.TrimSpace(raw), attributeKey)
+	raw = strings.TrimPrefix(raw, AttrKeyRID+":")
+
+	fields := strings.Fields(raw)
+	if len(fields) < 2 || len(fields) > 3 || !isRIDID(fields[0]) {
+		return fmt.Errorf("%w: %v", errInvalidRID, raw)
+	}
+	if fields[1] != RIDDirectionSend && fields[1] != RIDDirectionRecv {
+		return fmt.Errorf("%w: direction `%v`", errInvalidRID, fields[1])
+	}
+
+	rid := RID{ID: fields[0], Direction: fields[1]}
+	if len(fields) == 3 {
+		for param := range strings.SplitSeq(fields[2], ";") {
+			if err := rid.unmarshalParam(param); err != nil {
+				return err
+			}
+		}
+	}
+
+	*r = rid
+
+	return nil
+}
+
+func (r *RID) unmarshalParam(param string) error { //nolint:cyclop
+	key, value, _ := strings.Cut(param, "=")
+
+	var err error
+	switch key {
+	case ridParamPayloadTypes:
+		for format := range strings.SplitSeq(value, ",") {
+			var pt uint64
+			if pt, err = strconv.ParseUint(format, 10, 7); err != nil {
+				break
+			}
+			r.PayloadTypes = append(r.PayloadTypes, uint8(pt))
+		}
+	case ridParamMaxWidth:
+		r.MaxWidth, err = parseRIDUint(value)
+	case ridParamMaxHeight:
+		r.MaxHeight, err = parseRIDUint(value)
+	case ridParamMaxFPS:
+		r.MaxFPS, err = parseRIDFloat(value)
+	case ridParamMaxFS:
+		r.MaxFS, err = parseRIDUint(value)
+	case ridParamMaxBR:
+		r.MaxBR, err = parseRIDUint(value)
+	case ridParamMaxPPS:
+		r.MaxPPS, err = parseRIDUint(value)
+	case ridParamMaxBPP:
+		r.MaxBPP, err = parseRIDFloat(value)
+	case ridParamDepend:
+		r.Depend = strings.Split(value, ",")
+		if slices.ContainsFunc(r.Depend, func(id string) bool { return !isRIDID(id) }) {
+			err = errInvalidRID
+		}
+	default:
+		if key == "" {
+			err = errInvalidRID
+		}
+		r.Params = append(r.Params, RIDParam{Key: key, Value: value})
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w: `%v`", errInvalidRID, param)
+	}
+
+	return nil
+}
+
+func parseRIDUint(value string) (*uint32, error) {
+	v, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return nil, err
+	}
+	u := uint32(v)
+
+	return &u, nil
+}
+
+func parseRIDFloat(value string) (*float64, error) {
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+// isRIDID reports whether id is a valid rid-id.
+//
+//	rid-id = 1*(alpha-numeric / "-" / "_")
+func isRIDID(id string) bool {
+	if id == "" {
+		return false
+	}
+
+	for _, c := range []byte(id) {
+		if !isAlphaNumeric(c) && c != '-' && c != '_' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isAlphaNumeric(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// Marshal creates a string from a RID.
+func (r RID) Marshal() string {
+	return AttrKeyRID + ":" + r.String()
+}
+
+// String returns the value of the "a=rid" attribute. The restrictions are
+// written in a fixed order, followed by Params.
+func (r RID) String() string {
+	return stringFromMarshal(r.marshalInto, r.marshalSize)
+}
+
+func (r RID) marshalInto(b []byte) []byte {
+	b = append(append(append(b, r.ID...), ' '), r.Direction...)
+
+	sep := byte(' ')
+	appendKey := func(key string) {
+		b = append(append(append(b, sep), key...), '=')
+		sep = ';'
+	}
+
+	if len(r.PayloadTypes) > 0 {
+		appendKey(ridParamPayloadTypes)
+		for i, pt := range r.PayloadTypes {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = strconv.AppendUint(b, uint64(pt), 10)
+		}
+	}
+
+	for _, param := range r.uintParams() {
+		if param.value != nil {
+			appendKey(param.key)
+			b = strconv.AppendUint(b, uint64(*param.value), 10)
+		}
+	}
+
+	if r.MaxFPS != nil {
+		appendKey(ridParamMaxFPS)
+		b = appendRIDFloat(b, *r.MaxFPS)
+	}
+	if r.MaxBPP != nil {
+		appendKey(ridParamMaxBPP)
+		b = appendRIDFloat(b, *r.MaxBPP)
+	}
+
+	if len(r.Depend) > 0 {
+		appendKey(ridParamDepend)
+		b = append(b, strings.Join(r.Depend, ",")...)
+	}
+
+	for _, param := range r.Params {
+		b = append(append(b, sep), param.Key...)
+		if param.Value != "" {
+			b = append(append(b, '='), param.Value...)
+		}
+		sep = ';'
+	}
+
+	return b
+}
+
+func (r RID) marshalSize() (size int) { //nolint:cyclop
+	size = len(r.ID) + 1 + len(r.Direction)
+
+	// Every restriction is preceded by " " or ";".
+	if len(r.PayloadTypes) > 0 {
+		size += 1 + len(ridParamPayloadTypes) + 1 + len(r.PayloadTypes) - 1
+		for _, pt := range r.PayloadTypes {
+			size += lenUint(uint64(pt))
+		}
+	}
+
+	for _, param := range r.uintParams() {
+		if param.value != nil {
+			size += 1 + len(param.key) + 1 + lenUint(uint64(*param.value))
+		}
+	}
+
+	if r.MaxFPS != nil {
+		size += 1 + len(ridParamMaxFPS) + 1 + lenRIDFloat(*r.MaxFPS)
+	}
+	if r.MaxBPP != nil {
+		size += 1 + len(ridParamMaxBPP) + 1 + lenRIDFloat(*r.MaxBPP)
+	}
+
+	if len(r.Depend) > 0 {
+		size += 1 + len(ridParamDepend) + 1 + len(r.Depend) - 1
+		for _, id := range r.Depend {
+			size += len(id)
+		}
+	}
+
+	for _, param := range r.Params {
+		size += 1 + len(param.Key)
+		if param.Value != "" {
+			size += 1 + len(param.Value)
+		}
+	}
+
+	return size
+}
+
+func appendRIDFloat(b []byte, value float64) []byte {
+	return strconv.AppendFloat(b, value, 'f', -1, 64)
+}
+
+func lenRIDFloat(value float64) int {
+	var buf [24]byte
+
+	return len(appendRIDFloat(buf[:0], value))
+}
+
+type ridUintParam struct {
+	key   string
+	value *uint32
+}
+
+func (r RID) uintParams() []ridUintParam {
+	return []ridUintParam{
+		{ridParamMaxWidth, r.MaxWidth},
+		{ridParamMaxHeight, r.MaxHeight},
+		{ridParamMaxFS, r.MaxFS},
+		{ridParamMaxBR, r.MaxBR},
+		{ridParamMaxPPS, r.MaxPPS},
+	}
+}
+
+// SimulcastRID is a RID in a simulcast stream list.
+type SimulcastRID struct {
+	ID string
+	// Paused is set for a RID that is marked with "~".
+	Paused bool
+}
+
+// SimulcastStream is a simulcast stream, given as a list of alternative
+// RIDs of which one is used.
+type SimulcastStream []SimulcastRID
+
+// Simulcast represents the value of an "a=simulcast" attribute.
+//
+//	sc-value     = ( sc-send [SP sc-recv] ) / ( sc-recv [SP sc-send] )
+//	sc-str-list  = sc-alt-list *( ";" sc-alt-list )
+//	sc-alt-list  = sc-id *( "," sc-id )
+//	sc-id        = [sc-id-paused] rid-id
+//
+// https://datatracker.ietf.org/doc/html/rfc8853#section-5.1
+type Simulcast struct {
+	Send []SimulcastStream
+	Recv []SimulcastStream
+}
+
+// Unmarshal creates a Simulcast from a string. The "a=" and "simulcast:"
+// prefixes are optional.
+func (s *Simulcast) Unmarshal(raw string) error {
+	raw = strings.TrimPrefix(strings.TrimSpace(raw), attributeKey)
+	raw = strings.TrimPrefix(raw, AttrKeySimulcast+":")
+
+	fields := strings.Fields(raw)
+	if len(fields) != 2 && len(fields) != 4 {
+		return fmt.Errorf("%w: %v", errInvalidSimulcast, raw)
+	}
+
+	var simulcast Simulcast
+	for i := 0; i < len(fields); i += 2 {
+		streams, err := unmarshalSimulcastStreams(fields[i+1])
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case fields[i] == RIDDirectionSend && simulcast.Send == nil:
+			simulcast.Send = streams
+		case fields[i] == RIDDirectionRecv && simulcast.Recv == nil:
+			simulcast.Recv = streams
+		default:
+			return fmt.Errorf("%w: direction `%v`", errInvalidSimulcast, fields[i])
+		}
+	}
+
+	*s = simulcast
+
+	return nil
+}
+
+func unmarshalSimulcastStreams(raw string) ([]SimulcastStream, error) {
+	var streams []SimulcastStream
+	for alternatives := range strings.SplitSeq(raw, ";") {
+		var stream SimulcastStream
+		for id := range strings.SplitSeq(alternatives, ",") {
+			rid := SimulcastRID{ID: strings.TrimPrefix(id, "~")}
+			rid.Paused = len(rid.ID) != len(id)
+			if !isRIDID(rid.ID) {
+				return nil, fmt.Errorf("%w: rid `%v`", errInvalidSimulcast, id)
+			}
+			stream = append(stream, rid)
+		}
+		streams = append(streams, stream)
+	}
+
+	return streams, nil
+}
+
+// Marshal creates a string from a Simulcast.
+func (s Simulcast) Marshal() string {
+	return AttrKeySimulcast + ":" + s.String()
+}
+
+// String returns the value of the "a=simulcast" attribute, with the send
+// streams first.
+func (s Simulcast) String() string {
+	return stringFromMarshal(s.marshalInto, s.marshalSize)
+}
+
+func (s Simulcast) marshalInto(b []byte) []byte {
+	if len(s.Send) > 0 {
+		b = marshalSimulcastStreams(append(b, RIDDirectionSend+" "...), s.Send)
+	}
+	if len(s.Recv) > 0 {
+		if len(s.Send) > 0 {
+			b = append(b, ' ')
+		}
+		b = marshalSimulcastStreams(append(b, RIDDirectionRecv+" "...), s.Recv)
+	}
+
+	return b
+}
+
+func marshalSimulcastStreams(b []byte, streams []SimulcastStream) []byte {
+	for i, stream := range streams {
+		if i > 0 {
+			b = append(b, ';')
+		}
+		for j, rid := range stream {
+			if j > 0 {
+				b = append(b, ',')
+			}
+			if rid.Paused {
+				b = append(b, '~')
+			}
+			b = append(b, rid.ID...)
+		}
+	}
+
+	return b
+}
+
+func (s Simulcast) marshalSize() (size int) {
+	if len(s.Send) > 0 {
+		size += len(RIDDirectionSend) + 1 + simulcastStreamsSize(s.Send)
+	}
+	if len(s.Recv) > 0 {
+		if len(s.Send) > 0 {
+			size++
+		}
+		size += len(RIDDirectionRecv) + 1 + simulcastStreamsSize(s.Recv)
+	}
+
+	return size
+}
+
+func simulcastStreamsSize(streams []SimulcastStream) (size int) {
+	size = len(streams) - 1
+	for _, stream := range streams {
+		if len(stream) > 0 {
+			size += len(stream) - 1
+		}
+		for _, rid := range stream {
+			if rid.Paused {
+				size++
+			}
+			size += len(rid.ID)
+		}
+	}
+
+	return size
+}
+
+// RIDs parses and returns the "a=rid" attributes of the media description.
+func (d *MediaDescription) RIDs() ([]RID, error) {
+	var rids []RID
+	for _, a := range d.Attributes {
+		if a.Key != AttrKeyRID {
+			continue
+		}
+
+		var rid RID
+		if err := rid.Unmarshal(a.Value); err != nil {
+			return nil, err
+		}
+		rids = append(rids, rid)
+	}
+
+	return rids, nil
+}
+
+// WithRID adds an "a=rid" attribute to the media description.
+func (d *MediaDescription) WithRID(rid RID) *MediaDescription {
+	return d.WithValueAttribute(AttrKeyRID, rid.String())
+}
+
+// Simulcast parses and returns the "a=simulcast" attribute of the media
+// description. It returns nil when there is none.
+func (d *MediaDescription) Simulcast() (*Simulcast, error) {
+	value, ok := d.Attribute(AttrKeySimulcast)
+	if !ok {
+		return nil, nil //nolint:nilnil
+	}
+
+	simulcast := &Simulcast{}
+	if err := simulcast.Unmarshal(value); err != nil {
+		return nil, err
+	}
+
+	return simulcast, nil
+}
+
+// WithSimulcast adds an "a=simulcast" attribute to the media description.
+func (d *MediaDescription) WithSimulcast(simulcast Simulcast) *MediaDescription {
+	return d.WithValueAttribute(AttrKeySimulcast, simulcast.String())
+}
+
+// ValidateSimulcast checks the "a=rid" and "a=simulcast" attributes of the
+// media description: RIDs are declared once, only use payload types of the
+// m= line and depend on declared RIDs, and every RID of the simulcast
+// streams is declared with the same direction and used only once.
+// https://datatracker.ietf.org/doc/html/rfc8851#section-5
+// https://datatracker.ietf.org/doc/html/rfc8853#section-5.2
+func (d *MediaDescription) ValidateSimulcast() error { //nolint:cyclop
+	rids, err := d.RIDs()
+	if err != nil {
+		return err
+	}
+
+	declared := map[string]RID{}
+	for _, rid := range rids {
+		if _, ok := declared[rid.ID]; ok {
+			return fmt.Errorf("%w: %v", errRIDDuplicate, rid.ID)
+		}
+		declared[rid.ID] = rid
+
+		for _, pt := range rid.PayloadTypes {
+			if !slices.Contains(d.MediaName.Formats, strconv.Itoa(int(pt))) {
+				return fmt.Errorf("%w: %v %d", errRIDUnknownPayloadType, rid.ID, pt)
+			}
+		}
+	}
+
+	for _, rid := range rids {
+		for _, id := range rid.Depend {
+			if _, ok := declared[id]; !ok {
+				return fmt.Errorf("%w: %v %v", errRIDUnknownDependency, rid.ID, id)
+			}
+		}
+	}
+
+	simulcast, err := d.Simulcast()
+	if err != nil || simulcast == nil {
+		return err
+	}
+
+	used := map[string]bool{}
+	for _, list := range []struct {
+		direction string
+		streams   []SimulcastStream
+	}{
+		{RIDDirectionSend, simulcast.Send},
+		{RIDDirectionRecv, simulcast.Recv},
+	} {
+		direction := list.direction
+		for _, stream := range list.streams {
+			for _, sid := range stream {
+				rid, ok := declared[sid.ID]
+				switch {
+				case !ok:
+					return fmt.Errorf("%w: %v", errSimulcastUnknownRID, sid.ID)
+				case rid.Direction != direction:
+					return fmt.Errorf("%w: %v", errSimulcastRIDDirection, sid.ID)
+				case used[sid.ID]:
+					return fmt.Errorf("%w: %v", errSimulcastDuplicateStream, sid.ID)
+				}
+				used[sid.ID] = true
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,178 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRID_Unmarshal(t *testing.T) {
+	raw := "hi send pt=96,97;max-width=1280;max-height=720;max-br=2500000;max-fps=29.97;depend=lo;foo=bar;baz"
+	width, height, bitrate, fps := uint32(1280), uint32(720), uint32(2500000), 29.97
+
+	for _, value := range []string{raw, "a=rid:" + raw, "rid:" + raw} {
+		var rid RID
+		assert.NoError(t, rid.Unmarshal(value))
+		assert.Equal(t, RID{
+			ID:           "hi",
+			Direction:    RIDDirectionSend,
+			PayloadTypes: []uint8{96, 97},
+			MaxWidth:     &width,
+			MaxHeight:    &height,
+			MaxFPS:       &fps,
+			MaxBR:        &bitrate,
+			Depend:       []string{"lo"},
+			Params:       []RIDParam{{Key: "foo", Value: "bar"}, {Key: "baz"}},
+		}, rid)
+		assert.Equal(t,
+			"hi send pt=96,97;max-width=1280;max-height=720;max-br=2500000;max-fps=29.97;depend=lo;foo=bar;baz",
+			rid.String(),
+		)
+		assert.Equal(t, len(rid.String()), rid.marshalSize())
+	}
+
+	var rid RID
+	frameSize, packetRate, bitsPerPixel := uint32(3600), uint32(100), 1.5
+	assert.NoError(t, rid.Unmarshal("1 recv max-fs=3600;max-pps=100;max-bpp=1.5"))
+	assert.Equal(t, RID{
+		ID: "1", Direction: RIDDirectionRecv, MaxFS: &frameSize, MaxPPS: &packetRate, MaxBPP: &bitsPerPixel,
+	}, rid)
+	assert.Equal(t, "rid:1 recv max-fs=3600;max-pps=100;max-bpp=1.5", rid.Marshal())
+
+	// Restrictions set to zero are kept.
+	assert.NoError(t, rid.Unmarshal("z send max-br=0;max-fps=0;max-width=0"))
+	assert.Equal(t, "z send max-width=0;max-br=0;max-fps=0", rid.String())
+	assert.Equal(t, len(rid.String()), rid.marshalSize())
+
+	assert.NoError(t, rid.Unmarshal("q_-1 send"))
+	assert.Equal(t, "q_-1 send", rid.String())
+	assert.Equal(t, len(rid.String()), rid.marshalSize())
+
+	for _, value := range []string{
+		"",
+		"hi",
+		"hi sendrecv",
+		"h.i send",
+		"hi send pt=128",
+		"hi send pt=a",
+		"hi send max-width=-1",
+		"hi send max-fps=fast",
+		"hi send depend=a,",
+		"hi send ;",
+		"hi send max-width=1 extra",
+	} {
+		assert.ErrorIs(t, rid.Unmarshal(value), errInvalidRID, value)
+	}
+}
+
+func TestSimulcast_Unmarshal(t *testing.T) {
+	var simulcast Simulcast
+	assert.NoError(t, simulcast.Unmarshal("a=simulcast:send 1,~4;2;3 recv c"))
+	assert.Equal(t, Simulcast{
+		Send: []SimulcastStream{
+			{{ID: "1"}, {ID: "4", Paused: true}},
+			{{ID: "2"}},
+			{{ID: "3"}},
+		},
+		Recv: []SimulcastStream{{{ID: "c"}}},
+	}, simulcast)
+	assert.Equal(t, "simulcast:send 1,~4;2;3 recv c", simulcast.Marshal())
+	assert.Equal(t, len(simulcast.String()), simulcast.marshalSize())
+
+	assert.NoError(t, simulcast.Unmarshal("recv ~a;b send c"))
+	assert.Equal(t, "send c recv ~a;b", simulcast.String())
+	assert.Equal(t, len(simulcast.String()), simulcast.marshalSize())
+
+	assert.NoError(t, simulcast.Unmarshal("recv a;b"))
+	assert.Equal(t, Simulcast{Recv: []SimulcastStream{{{ID: "a"}}, {{ID: "b"}}}}, simulcast)
+	assert.Equal(t, "recv a;b", simulcast.String())
+	assert.Equal(t, len(simulcast.String()), simulcast.marshalSize())
+	assert.Equal(t, 0, Simulcast{}.marshalSize())
+
+	for _, value := range []string{
+		"",
+		"send",
+		"send a recv",
+		"send a send b",
+		"sendrecv a",
+		"send a;;b",
+		"send a,~",
+		"send a recv b send c",
+	} {
+		assert.ErrorIs(t, simulcast.Unmarshal(value), errInvalidSimulcast, value)
+	}
+}
+
+func TestMediaDescription_Simulcast(t *testing.T) {
+	md := NewJSEPMediaDescription("video", nil)
+	md.MediaName.Formats = []string{"96", "97"}
+	width := uint32(640)
+
+	simulcast, err := md.Simulcast()
+	assert.NoError(t, err)
+	assert.Nil(t, simulcast)
+	assert.NoError(t, md.ValidateSimulcast())
+
+	md.WithRID(RID{ID: "f", Direction: RIDDirectionSend, PayloadTypes: []uint8{96}}).
+		WithRID(RID{ID: "h", Direction: RIDDirectionSend, MaxWidth: &width, Depend: []string{"f"}}).
+		WithRID(RID{ID: "r", Direction: RIDDirectionRecv}).
+		WithSimulcast(Simulcast{
+			Send: []SimulcastStream{{{ID: "f"}}, {{ID: "h", Paused: true}}},
+			Recv: []SimulcastStream{{{ID: "r"}}},
+		})
+
+	value, ok := md.Attribute(AttrKeySimulcast)
+	assert.True(t, ok)
+	assert.Equal(t, "send f;~h recv r", value)
+
+	rids, err := md.RIDs()
+	assert.NoError(t, err)
+	assert.Len(t, rids, 3)
+	assert.Equal(t, "h send max-width=640;depend=f", rids[1].String())
+
+	simulcast, err = md.Simulcast()
+	assert.NoError(t, err)
+	assert.Len(t, simulcast.Send, 2)
+	assert.NoError(t, md.ValidateSimulcast())
+}
+
+func TestMediaDescription_ValidateSimulcast(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		attributes []string
+		err        error
+	}{
+		{"undeclared", []string{"rid:a send", "simulcast:send a;b"}, errSimulcastUnknownRID},
+		{"direction", []string{"rid:a send", "rid:b send", "simulcast:send a recv b"}, errSimulcastRIDDirection},
+		{"duplicate rid", []string{"rid:a send", "rid:a recv"}, errRIDDuplicate},
+		{"payload type", []string{"rid:a send pt=98"}, errRIDUnknownPayloadType},
+		{"dependency", []string{"rid:a send depend=b"}, errRIDUnknownDependency},
+		{"duplicate stream", []string{"rid:a send", "simulcast:send a;a"}, errSimulcastDuplicateStream},
+		{"invalid rid", []string{"rid:a"}, errInvalidRID},
+		{"invalid simulcast", []string{"rid:a send", "simulcast:a"}, errInvalidSimulcast},
+	} {
+		md := NewJSEPMediaDescription("video", nil)
+		md.MediaName.Formats = []string{"96"}
+		for _, attribute := range test.attributes {
+			key, value, _ := strings.Cut(attribute, ":")
+			md.WithValueAttribute(key, value)
+		}
+		assert.ErrorIs(t, md.ValidateSimulcast(), test.err, test.name)
+	}
+
+	var s SessionDescription
+	assert.NoError(t, s.UnmarshalString("v=0\r\n"+
+		"o=- 0 0 IN IP4 127.0.0.1\r\n"+
+		"s=-\r\n"+
+		"t=0 0\r\n"+
+		"m=video 9 UDP/TLS/RTP/SAVPF 96\r\n"+
+		"a=rid:q send\r\n"+
+		"a=rid:h send\r\n"+
+		"a=simulcast:send q;~h\r\n",
+	))
+	assert.NoError(t, s.MediaDescriptions[0].ValidateSimulcast())
+}
@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// SpecProfile is the specification a session description is parsed against.
+type SpecProfile int
+
+const (
+	// SpecProfileRFC4566 parses as the original SDP specification, with the
+	// media types, protos and bandwidth types that were registered at the
+	// time. It is the default.
+	// https://datatracker.ietf.org/doc/html/rfc4566
+	SpecProfileRFC4566 SpecProfile = iota + 1
+	// SpecProfileRFC8866 parses as the current SDP specification, see
+	// UnmarshalOptions.Profile.
+	// https://datatracker.ietf.org/doc/html/rfc8866
+	SpecProfileRFC8866
+)
+
+func (p SpecProfile) String() string {
+	switch p {
+	case SpecProfileRFC4566:
+		return "RFC 4566"
+	case SpecProfileRFC8866:
+		return "RFC 8866"
+	default:
+		return ""
+	}
+}
+
+// SpecProfile returns the specification the session description was parsed
+// against, SpecProfileRFC4566 unless UnmarshalOptions.Profile selected
+// another one. It returns zero for descriptions that were not parsed, such
+// as those constructed in code or decoded from JSON.
+func (s *SessionDescription) SpecProfile() SpecProfile {
+	return s.profile
+}
+
+func (l *lexer) rfc8866() bool {
+	return l.options.Profile == SpecProfileRFC8866
+}
+
+func checkTokenSyntax(value string) error {
+	if !isToken(value) {
+		return fmt.Errorf("%w `%v`", ErrSDPInvalidValue, value)
+	}
+
+	return nil
+}
+
+// checkAttribute checks that the name of an attribute is a token and that
+// its value is a byte-string, which excludes NUL besides CR and LF.
+//
+//	attribute = (attribute-name ":" attribute-value) / attribute-name
+//
+// https://datatracker.ietf.org/doc/html/rfc8866#section-9
+func checkAttribute(a Attribute) error {
+	if !isToken(a.Key) || strings.IndexByte(a.Value, 0) >= 0 {
+		return fmt.Errorf("%w `a=%v`", ErrSDPInvalidValue, a.Key)
+	}
+
+	return nil
+}
+
+// parseConnectionAddress splits the TTL and the number of addresses from a
+// c= connection address. Only multicast addresses carry them: an IP4
+// multicast address requires a TTL and may be followed by the number of
+// addresses, while an IP6 multicast address has no TTL and may only be
+// followed by the number of addresses.
+//
+//	IP4-multicast = m1 3( "." decimal-uchar ) "/" ttl [ "/" numaddr ]
+//	IP6-multicast = IP6-address [ "/" numaddr ]
+//
+// https://datatracker.ietf.org/doc/html/rfc8866#section-5.7
+func parseConnectionAddress(addressType, value string) (*Address, error) {
+	host, rest, hasSlash := strings.Cut(value, "/")
+	address := &Address{Address: host}
+	if addressType != "IP4" && addressType != "IP6" {
+		address.Address = value
+
+		return address, nil
+	}
+
+	ip, err := netip.ParseAddr(host)
+	multicast := err == nil && ip.IsMulticast() && ip.Is4() == (addressType == "IP4")
+	switch {
+	case multicast && addressType == "IP4" && !hasSlash:
+		return nil, fmt.Errorf("%w `%v`: missing TTL", ErrSDPInvalidValue, value)
+	case !multicast && hasSlash:
+		return nil, fmt.Errorf("%w `%v`: only multicast addresses have a TTL or range", ErrSDPInvalidValue, value)
+	case !hasSlash:
+		return address, nil
+	}
+
+	fields := strings.Split(rest, "/")
+	if addressType == "IP4" {
+		ttl, err := strconv.ParseUint(fields[0], 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("%w `%v`", ErrSDPInvalidNumericValue, fields[0])
+		}
+		ttlValue := int(ttl)
+		address.TTL = &ttlValue
+		fields = fields[1:]
+	}
+
+	switch len(fields) {
+	case 0:
+		return address, nil
+	case 1:
+		numAddresses, err := strconv.ParseUint(fields[0], 10, 31)
+		if err != nil || numAddresses == 0 {
+			return nil, fmt.Errorf("%w `%v`", ErrSDPInvalidNumericValue, fields[0])
+		}
+		numAddressesValue := int(numAddresses)
+		address.Range = &numAddressesValue
+
+		return address, nil
+	default:
+		return nil, fmt.Errorf("%w `%v`", ErrSDPInvalidValue, value)
+	}
+}
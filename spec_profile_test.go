@@ -0,0 +1,195 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const specProfileHeader = "v=0\r\n" +
+	"o=jdoe 2890844526 2890842807 IN IP4 198.51.100.1\r\n" +
+	"s=SDP Seminar\r\n"
+
+func unmarshalRFC8866(value string) (*SessionDescription, error) {
+	s := &SessionDescription{}
+	_, err := s.UnmarshalStringWithOptions(value, UnmarshalOptions{Profile: SpecProfileRFC8866})
+
+	return s, err
+}
+
+func TestSpecProfile_String(t *testing.T) {
+	assert.Equal(t, "RFC 4566", SpecProfileRFC4566.String())
+	assert.Equal(t, "RFC 8866", SpecProfileRFC8866.String())
+	assert.Equal(t, "", SpecProfile(0).String())
+}
+
+func TestSessionDescription_SpecProfile(t *testing.T) {
+	value := specProfileHeader + "t=0 0\r\n"
+
+	assert.Equal(t, SpecProfile(0), (&SessionDescription{}).SpecProfile())
+
+	// Parsing with the default options records the default profile.
+	var s SessionDescription
+	assert.NoError(t, s.UnmarshalString(value))
+	assert.Equal(t, SpecProfileRFC4566, s.SpecProfile())
+
+	var withOptions SessionDescription
+	_, err := withOptions.UnmarshalStringWithOptions(value, UnmarshalOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, SpecProfileRFC4566, withOptions.SpecProfile())
+
+	var explicit SessionDescription
+	_, err = explicit.UnmarshalStringWithOptions(value, UnmarshalOptions{Profile: SpecProfileRFC4566})
+	assert.NoError(t, err)
+	assert.Equal(t, SpecProfileRFC4566, explicit.SpecProfile())
+	assert.Equal(t, SpecProfileRFC4566, explicit.Clone().SpecProfile())
+
+	parsed, err := unmarshalRFC8866(value)
+	assert.NoError(t, err)
+	assert.Equal(t, SpecProfileRFC8866, parsed.SpecProfile())
+
+	// Parsing again with the default profile replaces it.
+	assert.NoError(t, parsed.UnmarshalString(value))
+	assert.Equal(t, SpecProfileRFC4566, parsed.SpecProfile())
+}
+
+func TestSpecProfileRFC8866_Media(t *testing.T) {
+	value := specProfileHeader + "t=0 0\r\n" + "m=haptics 49170 RTP/AVP 96\r\n"
+
+	var s SessionDescription
+	assert.ErrorIs(t, s.UnmarshalString(value), ErrSDPInvalidValue)
+
+	parsed, err := unmarshalRFC8866(value)
+	assert.NoError(t, err)
+	if assert.Len(t, parsed.MediaDescriptions, 1) {
+		assert.Equal(t, "haptics", parsed.MediaDescriptions[0].MediaName.Media)
+	}
+
+	_, err = unmarshalRFC8866(specProfileHeader + "t=0 0\r\n" + "m=audio 49170 RTP/AVP 9\"6\r\n")
+	assert.ErrorIs(t, err, ErrSDPInvalidValue)
+}
+
+func TestSpecProfileRFC8866_Bandwidth(t *testing.T) {
+	value := specProfileHeader + "b=FOO:128\r\nt=0 0\r\nm=audio 49170 RTP/AVP 0\r\nb=BAR:64\r\n"
+
+	var s SessionDescription
+	assert.ErrorIs(t, s.UnmarshalString(value), ErrSDPInvalidValue)
+
+	parsed, err := unmarshalRFC8866(value)
+	assert.NoError(t, err)
+	assert.Equal(t, []Bandwidth{{Type: "FOO", Bandwidth: 128}}, parsed.Bandwidth)
+	assert.Equal(t, []Bandwidth{{Type: "BAR", Bandwidth: 64}}, parsed.MediaDescriptions[0].Bandwidth)
+}
+
+func TestSpecProfileRFC8866_ConnectionAddress(t *testing.T) {
+	ttl, numAddresses := 127, 3
+	for _, test := range []struct {
+		Line    string
+		Address *Address
+		Err     error
+	}{
+		{Line: "IN IP4 198.51.100.1", Address: &Address{Address: "198.51.100.1"}},
+		{Line: "IN IP4 host.example.com", Address: &Address{Address: "host.example.com"}},
+		{Line: "IN IP4 233.252.0.1/127", Address: &Address{Address: "233.252.0.1", TTL: &ttl}},
+		{
+			Line:    "IN IP4 233.252.0.1/127/3",
+			Address: &Address{Address: "233.252.0.1", TTL: &ttl, Range: &numAddresses},
+		},
+		{Line: "IN IP6 2001:db8::1", Address: &Address{Address: "2001:db8::1"}},
+		{Line: "IN IP6 ff00::db8:0:101", Address: &Address{Address: "ff00::db8:0:101"}},
+		{Line: "IN IP6 ff00::db8:0:101/3", Address: &Address{Address: "ff00::db8:0:101", Range: &numAddresses}},
+		{Line: "IN IP4 233.252.0.1", Err: ErrSDPInvalidValue},
+		{Line: "IN IP4 233.252.0.1/256", Err: ErrSDPInvalidNumericValue},
+		{Line: "IN IP4 233.252.0.1/127/0", Err: ErrSDPInvalidNumericValue},
+		{Line: "IN IP4 233.252.0.1/127/3/1", Err: ErrSDPInvalidValue},
+		{Line: "IN IP4 198.51.100.1/127", Err: ErrSDPInvalidValue},
+		{Line: "IN IP6 ff00::db8:0:101/127/3", Err: ErrSDPInvalidValue},
+		{Line: "IN IP6 2001:db8::1/3", Err: ErrSDPInvalidValue},
+	} {
+		t.Run(test.Line, func(t *testing.T) {
+			parsed, err := unmarshalRFC8866(specProfileHeader + "c=" + test.Line + "\r\nt=0 0\r\n")
+			if test.Err != nil {
+				assert.ErrorIs(t, err, test.Err)
+
+				return
+			}
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, test.Address, parsed.ConnectionInformation.Address)
+				assert.Equal(t, test.Line, parsed.ConnectionInformation.String())
+			}
+		})
+	}
+
+	// RFC 4566 parsing keeps the address as written.
+	var s SessionDescription
+	assert.NoError(t, s.UnmarshalString(specProfileHeader+"c=IN IP6 ff00::db8:0:101/127/3\r\nt=0 0\r\n"))
+	assert.Equal(t, &Address{Address: "ff00::db8:0:101/127/3"}, s.ConnectionInformation.Address)
+}
+
+func TestSpecProfileRFC8866_TimeZones(t *testing.T) {
+	parsed, err := unmarshalRFC8866(specProfileHeader + "t=0 0\r\nz=2882844526 -1h 2898848070 0\r\n")
+	assert.NoError(t, err)
+	assert.Equal(t, []TimeZone{
+		{AdjustmentTime: 2882844526, Offset: -3600},
+		{AdjustmentTime: 2898848070, Offset: 0},
+	}, parsed.TimeZones)
+
+	for _, line := range []string{
+		"z=2882844526 -1h 2898848070",
+		"z=",
+		"z=2882844526 +1h",
+	} {
+		t.Run(line, func(t *testing.T) {
+			value := specProfileHeader + "t=0 0\r\n" + line + "\r\n"
+
+			_, err := unmarshalRFC8866(value)
+			assert.ErrorIs(t, err, ErrSDPInvalidValue)
+
+			var s SessionDescription
+			assert.NoError(t, s.UnmarshalString(value))
+		})
+	}
+}
+
+func TestSpecProfileRFC8866_Attributes(t *testing.T) {
+	parsed, err := unmarshalRFC8866(specProfileHeader + "t=0 0\r\na=tool:x\x7f\xff y\r\n")
+	assert.NoError(t, err)
+	assert.Equal(t, []Attribute{NewAttribute("tool", "x\x7f\xff y")}, parsed.Attributes)
+
+	for _, line := range []string{
+		"a=:value",
+		"a=na(me):value",
+		"a=tool:x\x00y",
+	} {
+		t.Run(line, func(t *testing.T) {
+			value := specProfileHeader + "t=0 0\r\n" + line + "\r\nm=audio 49170 RTP/AVP 0\r\n" + line + "\r\n"
+
+			_, err := unmarshalRFC8866(value)
+			assert.ErrorIs(t, err, ErrSDPInvalidValue)
+
+			// The media-level attribute is checked too.
+			_, err = unmarshalRFC8866(specProfileHeader + "t=0 0\r\nm=audio 49170 RTP/AVP 0\r\n" + line + "\r\n")
+			assert.ErrorIs(t, err, ErrSDPInvalidValue)
+
+			var s SessionDescription
+			assert.NoError(t, s.UnmarshalString(value))
+		})
+	}
+}
+
+func TestSpecProfileRFC8866_Lenient(t *testing.T) {
+	var s SessionDescription
+	warnings, err := s.UnmarshalStringWithOptions(
+		specProfileHeader+"c=IN IP4 233.252.0.1\r\nt=0 0\r\na=:x\r\nm=audio 49170 RTP/AVP 0\r\n",
+		UnmarshalOptions{Lenient: true, Profile: SpecProfileRFC8866},
+	)
+	assert.NoError(t, err)
+	assert.Len(t, warnings, 2)
+	assert.Nil(t, s.ConnectionInformation)
+	assert.Empty(t, s.Attributes)
+	assert.Len(t, s.MediaDescriptions, 1)
+}
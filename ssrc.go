@@ -0,0 +1,457 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	ssrcAttributeCNAME = "cname"
+
+	// msidNoStream is the stream ID of a track that is not part of a stream.
+	// https://datatracker.ietf.org/doc/html/rfc8829#section-5.2.1
+	msidNoStream = "-"
+
+	maxMsidLength = 64
+)
+
+var (
+	errInvalidSSRC      = errors.New("sdp: invalid ssrc")
+	errInvalidSSRCGroup = errors.New("sdp: invalid ssrc-group")
+	errInvalidMsid      = errors.New("sdp: invalid msid")
+	errSSRCNotFound     = errors.New("sdp: ssrc not found")
+)
+
+// SSRC represents the "a=ssrc" attributes of an RTP source, which describe
+// the source with attribute pairs such as "cname" and "msid".
+//
+//	ssrc-attr = "ssrc:" ssrc-id SP attribute
+//	ssrc-id   = integer ; 0 .. 2**32 - 1
+//
+// https://datatracker.ietf.org/doc/html/rfc5576#section-4.1
+type SSRC struct {
+	ID         uint32
+	Attributes []Attribute
+}
+
+// unmarshalSSRCLine parses the value of an "a=ssrc" attribute into the SSRC
+// and one of its attributes.
+func unmarshalSSRCLine(raw string) (uint32, Attribute, error) {
+	id, attribute, found := strings.Cut(strings.TrimSpace(raw), " ")
+	ssrc, err := strconv.ParseUint(id, 10, 32)
+	if err != nil || !found || attribute == "" {
+		return 0, Attribute{}, fmt.Errorf("%w: %v", errInvalidSSRC, raw)
+	}
+
+	key, value, _ := strings.Cut(attribute, ":")
+
+	return uint32(ssrc), Attribute{Key: key, Value: value}, nil
+}
+
+// Attribute returns the value of the attribute of the SSRC with the given
+// key, and whether it is present.
+func (s SSRC) Attribute(key string) (string, bool) {
+	for _, a := range s.Attributes {
+		if a.Key == key {
+			return a.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// CNAME returns the canonical name of the SSRC, or an empty string if it has
+// none.
+func (s SSRC) CNAME() string {
+	cname, _ := s.Attribute(ssrcAttributeCNAME)
+
+	return cname
+}
+
+// Msid returns the source-level "msid" attribute of the SSRC used by Plan B,
+// and whether it is present.
+func (s SSRC) Msid() (Msid, bool, error) {
+	value, ok := s.Attribute(AttrKeyMsid)
+	if !ok {
+		return Msid{}, false, nil
+	}
+
+	var msid Msid
+	if err := msid.Unmarshal(value); err != nil {
+		return Msid{}, false, err
+	}
+
+	return msid, true, nil
+}
+
+// SSRCGroup represents an "a=ssrc-group" attribute, which relates RTP
+// sources, for example a primary SSRC and its RTX SSRC with
+// SemanticTokenFlowIdentification.
+//
+//	ssrc-group-attr = "ssrc-group:" semantics *(SP ssrc-id)
+//
+// https://datatracker.ietf.org/doc/html/rfc5576#section-4.2
+type SSRCGroup struct {
+	Semantics string
+	SSRCs     []uint32
+}
+
+// Unmarshal creates an SSRCGroup from a string. The "a=" and "ssrc-group:"
+// prefixes are optional.
+func (g *SSRCGroup) Unmarshal(raw string) error {
+	raw = strings.TrimPrefix(strings.TrimSpace(raw), attributeKey)
+	raw = strings.TrimPrefix(raw, AttrKeySSRCGroup+":")
+
+	fields := strings.Fields(raw)
+	if len(fields) == 0 {
+		return fmt.Errorf("%w: %v", errInvalidSSRCGroup, raw)
+	}
+
+	ssrcs := make([]uint32, 0, len(fields)-1)
+	for _, field := range fields[1:] {
+		ssrc, err := strconv.ParseUint(field, 10, 32)
+		if err != nil {
+			return fmt.Errorf("%w: ssrc `%v`", errInvalidSSRCGroup, field)
+		}
+		ssrcs = append(ssrcs, uint32(ssrc))
+	}
+
+	g.Semantics = fields[0]
+	g.SSRCs = ssrcs
+
+	return nil
+}
+
+// Marshal creates a string from an SSRCGroup.
+func (g SSRCGroup) Marshal() string {
+	return AttrKeySSRCGroup + ":" + g.String()
+}
+
+// String returns the value of the "a=ssrc-group" attribute.
+func (g SSRCGroup) String() string {
+	return stringFromMarshal(g.marshalInto, g.marshalSize)
+}
+
+func (g SSRCGroup) marshalInto(b []byte) []byte {
+	b = append(b, g.Semantics...)
+	for _, ssrc := range g.SSRCs {
+		b = strconv.AppendUint(append(b, ' '), uint64(ssrc), 10)
+	}
+
+	return b
+}
+
+func (g SSRCGroup) marshalSize() (size int) {
+	size = len(g.Semantics)
+	for _, ssrc := range g.SSRCs {
+		size += 1 + lenUint(uint64(ssrc))
+	}
+
+	return size
+}
+
+// Msid represents the value of an "a=msid" attribute, which associates the
+// media description with a media stream and a track.
+//
+//	msid-value   = msid-id [ SP msid-appdata ]
+//	msid-id      = 1*64token-char
+//	msid-appdata = 1*64token-char
+//
+// https://datatracker.ietf.org/doc/html/rfc8830#section-2
+type Msid struct {
+	// StreamID is "-" for a track that is not part of a stream.
+	StreamID string
+	// TrackID is the optional application data, the track ID in WebRTC.
+	TrackID string
+}
+
+// Unmarshal creates an Msid from a string. The "a=" and "msid:" prefixes are
+// optional.
+func (m *Msid) Unmarshal(raw string) error {
+	raw = strings.TrimPrefix(strings.TrimSpace(raw), attributeKey)
+	raw = strings.TrimPrefix(raw, AttrKeyMsid+":")
+
+	fields := strings.Fields(raw)
+	if len(fields) == 0 || len(fields) > 2 || slices.ContainsFunc(fields, func(field string) bool {
+		return len(field) > maxMsidLength || !isToken(field)
+	}) {
+		return fmt.Errorf("%w: %v", errInvalidMsid, raw)
+	}
+
+	m.StreamID = fields[0]
+	m.TrackID = ""
+	if len(fields) == 2 {
+		m.TrackID = fields[1]
+	}
+
+	return nil
+}
+
+// Marshal creates a string from an Msid.
+func (m Msid) Marshal() string {
+	return AttrKeyMsid + ":" + m.String()
+}
+
+// String returns the value of the "a=msid" attribute.
+func (m Msid) String() string {
+	if m.TrackID == "" {
+		return m.StreamID
+	}
+
+	return m.StreamID + " " + m.TrackID
+}
+
+// isToken reports whether value is a non-empty token.
+//
+//	token-char = %x21 / %x23-27 / %x2A-2B / %x2D-2E / %x30-39
+//	             / %x41-5A / %x5E-7E
+//
+// https://datatracker.ietf.org/doc/html/rfc8866#section-9
+func isToken(value string) bool {
+	if value == "" {
+		return false
+	}
+
+	for _, c := range []byte(value) {
+		switch {
+		case c == 0x21, c >= 0x23 && c <= 0x27, c == 0x2a, c == 0x2b, c == 0x2d, c == 0x2e,
+			c >= 0x30 && c <= 0x39, c >= 0x41 && c <= 0x5a, c >= 0x5e && c <= 0x7e:
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// SSRCs parses and returns the RTP sources of the "a=ssrc" attributes of the
+// media description, in the order they first appear. The attributes of a
+// source are collected from all its "a=ssrc" lines.
+func (d *MediaDescription) SSRCs() ([]SSRC, error) {
+	var ssrcs []SSRC
+	for _, a := range d.Attributes {
+		if a.Key != AttrKeySSRC {
+			continue
+		}
+
+		id, attribute, err := unmarshalSSRCLine(a.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		i := slices.IndexFunc(ssrcs, func(s SSRC) bool { return s.ID == id })
+		if i < 0 {
+			i = len(ssrcs)
+			ssrcs = append(ssrcs, SSRC{ID: id})
+		}
+		ssrcs[i].Attributes = append(ssrcs[i].Attributes, attribute)
+	}
+
+	return ssrcs, nil
+}
+
+// WithSSRC adds an "a=ssrc" attribute for every attribute of the SSRC to the
+// media description.
+func (d *MediaDescription) WithSSRC(ssrc SSRC) *MediaDescription {
+	id := strconv.FormatUint(uint64(ssrc.ID), 10)
+	for _, a := range ssrc.Attributes {
+		if a.Value == "" {
+			d.WithValueAttribute(AttrKeySSRC, id+" "+a.Key)
+		} else {
+			d.WithValueAttribute(AttrKeySSRC, id+" "+a.Key+":"+a.Value)
+		}
+	}
+
+	return d
+}
+
+// SSRCGroups parses and returns the "a=ssrc-group" attributes of the media
+// description.
+func (d *MediaDescription) SSRCGroups() ([]SSRCGroup, error) {
+	var groups []SSRCGroup
+	for _, a := range d.Attributes {
+		if a.Key != AttrKeySSRCGroup {
+			continue
+		}
+
+		var group SSRCGroup
+		if err := group.Unmarshal(a.Value); err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+
+	return groups, nil
+}
+
+// WithSSRCGroup adds an "a=ssrc-group" attribute to the media description.
+func (d *MediaDescription) WithSSRCGroup(g SSRCGroup) *MediaDescription {
+	return d.WithValueAttribute(AttrKeySSRCGroup, g.String())
+}
+
+// RepairSSRC returns the SSRC that repairs the primary SSRC in an
+// "a=ssrc-group" with the given semantics, such as
+// SemanticTokenFlowIdentification for RTX or
+// SemanticTokenForwardErrorCorrectionFramework for FEC. The primary SSRC is
+// the first SSRC of the group.
+// https://datatracker.ietf.org/doc/html/rfc4588#section-8.3
+// https://datatracker.ietf.org/doc/html/rfc5956#section-4.3
+func (d *MediaDescription) RepairSSRC(semantics string, primary uint32) (uint32, error) {
+	groups, err := d.SSRCGroups()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, group := range groups {
+		if group.Semantics == semantics && len(group.SSRCs) > 1 && group.SSRCs[0] == primary {
+			return group.SSRCs[1], nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: %v repair of %d", errSSRCNotFound, semantics, primary)
+}
+
+// RTXSSRC returns the RTX SSRC of the primary SSRC.
+func (d *MediaDescription) RTXSSRC(primary uint32) (uint32, error) {
+	return d.RepairSSRC(SemanticTokenFlowIdentification, primary)
+}
+
+// Msids parses and returns the "a=msid" attributes of the media
+// description.
+func (d *MediaDescription) Msids() ([]Msid, error) {
+	var msids []Msid
+	for _, a := range d.Attributes {
+		if a.Key != AttrKeyMsid {
+			continue
+		}
+
+		var msid Msid
+		if err := msid.Unmarshal(a.Value); err != nil {
+			return nil, err
+		}
+		msids = append(msids, msid)
+	}
+
+	return msids, nil
+}
+
+// WithMsid adds an "a=msid" attribute to the media description.
+func (d *MediaDescription) WithMsid(msid Msid) *MediaDescription {
+	return d.WithValueAttribute(AttrKeyMsid, msid.String())
+}
+
+// MediaStreamTrack is a track of a MediaStream.
+type MediaStreamTrack struct {
+	ID string
+	// MID and Kind are the "a=mid" attribute and the media type of the
+	// media description of the track.
+	MID  string
+	Kind string
+	// SSRCs are the RTP sources of the track, including the repair SSRCs.
+	SSRCs []uint32
+}
+
+// MediaStream is a media stream of a session description.
+type MediaStream struct {
+	ID     string
+	Tracks []MediaStreamTrack
+}
+
+// MediaStreams returns the media streams of the session, in the order they
+// first appear. The tracks of a media description are taken from its
+// "a=msid" attributes, or from the source-level "msid" attributes of its
+// SSRCs as used by Plan B. Rejected media descriptions and tracks that are
+// not part of a stream are skipped.
+func (s *SessionDescription) MediaStreams() ([]MediaStream, error) {
+	var streams []MediaStream
+	add := func(msid Msid, track MediaStreamTrack) {
+		if msid.StreamID == msidNoStream {
+			return
+		}
+
+		i := slices.IndexFunc(streams, func(stream MediaStream) bool { return stream.ID == msid.StreamID })
+		if i < 0 {
+			i = len(streams)
+			streams = append(streams, MediaStream{ID: msid.StreamID})
+		}
+		streams[i].Tracks = append(streams[i].Tracks, track)
+	}
+
+	for _, md := range s.MediaDescriptions {
+		if md.IsRejected() {
+			continue
+		}
+
+		tracks, err := md.mediaStreamTracks()
+		if err != nil {
+			return nil, err
+		}
+		for _, track := range tracks {
+			add(track.msid, track.MediaStreamTrack)
+		}
+	}
+
+	return streams, nil
+}
+
+type msidTrack struct {
+	MediaStreamTrack
+	msid Msid
+}
+
+func (d *MediaDescription) mediaStreamTracks() ([]msidTrack, error) {
+	msids, err := d.Msids()
+	if err != nil {
+		return nil, err
+	}
+	ssrcs, err := d.SSRCs()
+	if err != nil {
+		return nil, err
+	}
+
+	mid, _ := d.Attribute(AttrKeyMID)
+	newTrack := func(msid Msid) msidTrack {
+		return msidTrack{
+			MediaStreamTrack: MediaStreamTrack{ID: msid.TrackID, MID: mid, Kind: d.MediaName.Media},
+			msid:             msid,
+		}
+	}
+
+	var tracks []msidTrack
+	if len(msids) > 0 {
+		for _, msid := range msids {
+			track := newTrack(msid)
+			for _, ssrc := range ssrcs {
+				track.SSRCs = append(track.SSRCs, ssrc.ID)
+			}
+			tracks = append(tracks, track)
+		}
+
+		return tracks, nil
+	}
+
+	for _, ssrc := range ssrcs {
+		msid, ok, err := ssrc.Msid()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		i := slices.IndexFunc(tracks, func(track msidTrack) bool { return track.msid == msid })
+		if i < 0 {
+			i = len(tracks)
+			tracks = append(tracks, newTrack(msid))
+		}
+		tracks[i].SSRCs = append(tracks[i].SSRCs, ssrc.ID)
+	}
+
+	return tracks, nil
+}
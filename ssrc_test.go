@@ -0,0 +1,179 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const planBSDP = "v=0\r\n" +
+	"o=- 0 0 IN IP4 127.0.0.1\r\n" +
+	"s=-\r\n" +
+	"t=0 0\r\n" +
+	"a=group:BUNDLE audio video\r\n" +
+	"a=msid-semantic: WMS stream\r\n" +
+	"m=audio 9 UDP/TLS/RTP/SAVPF 111\r\n" +
+	"a=mid:audio\r\n" +
+	"a=ssrc:1000 cname:user@example\r\n" +
+	"a=ssrc:1000 msid:stream audio0\r\n" +
+	"m=video 9 UDP/TLS/RTP/SAVPF 96 97\r\n" +
+	"a=mid:video\r\n" +
+	"a=ssrc-group:FID 2000 2001\r\n" +
+	"a=ssrc-group:FID 3000 3001\r\n" +
+	"a=ssrc:2000 cname:user@example\r\n" +
+	"a=ssrc:2000 msid:stream video0\r\n" +
+	"a=ssrc:2001 cname:user@example\r\n" +
+	"a=ssrc:2001 msid:stream video0\r\n" +
+	"a=ssrc:3000 msid:screen video1\r\n" +
+	"a=ssrc:3001 msid:screen video1\r\n" +
+	"a=ssrc:4000 cname:user@example\r\n"
+
+func TestSSRCGroup_Unmarshal(t *testing.T) {
+	for _, value := range []string{"FID 2000 2001", "a=ssrc-group:FID 2000 2001", "ssrc-group:FID 2000 2001"} {
+		var group SSRCGroup
+		assert.NoError(t, group.Unmarshal(value))
+		assert.Equal(t, SSRCGroup{Semantics: SemanticTokenFlowIdentification, SSRCs: []uint32{2000, 2001}}, group)
+		assert.Equal(t, "ssrc-group:FID 2000 2001", group.Marshal())
+	}
+
+	group := SSRCGroup{Semantics: SemanticTokenSimulcast, SSRCs: []uint32{1, 4294967295}}
+	assert.Equal(t, "SIM 1 4294967295", group.String())
+
+	for _, value := range []string{"", "FID 1 -2", "FID 4294967296", "FID x"} {
+		assert.ErrorIs(t, group.Unmarshal(value), errInvalidSSRCGroup, value)
+	}
+}
+
+func TestMsid_Unmarshal(t *testing.T) {
+	var msid Msid
+	assert.NoError(t, msid.Unmarshal("a=msid:stream track"))
+	assert.Equal(t, Msid{StreamID: "stream", TrackID: "track"}, msid)
+	assert.Equal(t, "msid:stream track", msid.Marshal())
+
+	assert.NoError(t, msid.Unmarshal("{7a6a7cb8-02ef-4a9e-a8e1-fa1d4b1e3a2f}"))
+	assert.Equal(t, Msid{StreamID: "{7a6a7cb8-02ef-4a9e-a8e1-fa1d4b1e3a2f}"}, msid)
+	assert.Equal(t, "{7a6a7cb8-02ef-4a9e-a8e1-fa1d4b1e3a2f}", msid.String())
+
+	for _, value := range []string{"", "a b c", "a\"b", strings.Repeat("a", 65)} {
+		assert.ErrorIs(t, msid.Unmarshal(value), errInvalidMsid, value)
+	}
+}
+
+func TestMediaDescription_SSRCs(t *testing.T) {
+	var s SessionDescription
+	assert.NoError(t, s.UnmarshalString(planBSDP))
+	video := s.MediaDescriptions[1]
+
+	ssrcs, err := video.SSRCs()
+	assert.NoError(t, err)
+	assert.Len(t, ssrcs, 5)
+	assert.Equal(t, SSRC{ID: 2000, Attributes: []Attribute{
+		{Key: "cname", Value: "user@example"},
+		{Key: "msid", Value: "stream video0"},
+	}}, ssrcs[0])
+	assert.Equal(t, "user@example", ssrcs[0].CNAME())
+	assert.Empty(t, ssrcs[2].CNAME())
+
+	msid, ok, err := ssrcs[2].Msid()
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, Msid{StreamID: "screen", TrackID: "video1"}, msid)
+	_, ok, err = ssrcs[4].Msid()
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	groups, err := video.SSRCGroups()
+	assert.NoError(t, err)
+	assert.Len(t, groups, 2)
+
+	rtx, err := video.RTXSSRC(3000)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(3001), rtx)
+	_, err = video.RTXSSRC(3001)
+	assert.ErrorIs(t, err, errSSRCNotFound)
+	_, err = video.RepairSSRC(SemanticTokenForwardErrorCorrectionFramework, 2000)
+	assert.ErrorIs(t, err, errSSRCNotFound)
+
+	video.Attributes = append(video.Attributes, NewAttribute(AttrKeySSRC, "1x cname:a"))
+	_, err = video.SSRCs()
+	assert.ErrorIs(t, err, errInvalidSSRC)
+	for _, value := range []string{"1", "1 ", "-1 cname:a"} {
+		_, _, err = unmarshalSSRCLine(value)
+		assert.ErrorIs(t, err, errInvalidSSRC, value)
+	}
+}
+
+func TestMediaDescription_WithSSRC(t *testing.T) {
+	md := (&MediaDescription{}).
+		WithSSRC(SSRC{ID: 1234, Attributes: []Attribute{
+			{Key: "cname", Value: "c"},
+			{Key: "msid", Value: "s t"},
+			{Key: "flag"},
+		}}).
+		WithSSRCGroup(SSRCGroup{Semantics: SemanticTokenForwardErrorCorrectionFramework, SSRCs: []uint32{1234, 5678}}).
+		WithMsid(Msid{StreamID: "s", TrackID: "t"})
+
+	assert.Equal(t, []Attribute{
+		{Key: "ssrc", Value: "1234 cname:c"},
+		{Key: "ssrc", Value: "1234 msid:s t"},
+		{Key: "ssrc", Value: "1234 flag"},
+		{Key: "ssrc-group", Value: "FEC-FR 1234 5678"},
+		{Key: "msid", Value: "s t"},
+	}, md.Attributes)
+
+	ssrcs, err := md.SSRCs()
+	assert.NoError(t, err)
+	assert.Equal(t, []SSRC{{ID: 1234, Attributes: []Attribute{
+		{Key: "cname", Value: "c"},
+		{Key: "msid", Value: "s t"},
+		{Key: "flag"},
+	}}}, ssrcs)
+
+	repair, err := md.RepairSSRC(SemanticTokenForwardErrorCorrectionFramework, 1234)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(5678), repair)
+}
+
+func TestSessionDescription_MediaStreams(t *testing.T) {
+	var s SessionDescription
+	assert.NoError(t, s.UnmarshalString(planBSDP))
+
+	streams, err := s.MediaStreams()
+	assert.NoError(t, err)
+	assert.Equal(t, []MediaStream{
+		{ID: "stream", Tracks: []MediaStreamTrack{
+			{ID: "audio0", MID: "audio", Kind: "audio", SSRCs: []uint32{1000}},
+			{ID: "video0", MID: "video", Kind: "video", SSRCs: []uint32{2000, 2001}},
+		}},
+		{ID: "screen", Tracks: []MediaStreamTrack{
+			{ID: "video1", MID: "video", Kind: "video", SSRCs: []uint32{3000, 3001}},
+		}},
+	}, streams)
+
+	unified := &SessionDescription{}
+	unified.WithMedia((&MediaDescription{MediaName: MediaName{Media: "audio", Port: RangedPort{Value: 9}}}).
+		WithValueAttribute(AttrKeyMID, "0").
+		WithMsid(Msid{StreamID: "s", TrackID: "a"}).
+		WithMsid(Msid{StreamID: "-", TrackID: "a"}).
+		WithSSRC(SSRC{ID: 1, Attributes: []Attribute{{Key: "cname", Value: "c"}}}))
+	unified.WithMedia((&MediaDescription{MediaName: MediaName{Media: "video", Port: RangedPort{Value: 9}}}).
+		WithValueAttribute(AttrKeyMID, "1").
+		WithMsid(Msid{StreamID: "s"}))
+	unified.WithMedia((&MediaDescription{MediaName: MediaName{Media: "video"}}).
+		WithMsid(Msid{StreamID: "rejected", TrackID: "v"}))
+
+	streams, err = unified.MediaStreams()
+	assert.NoError(t, err)
+	assert.Equal(t, []MediaStream{{ID: "s", Tracks: []MediaStreamTrack{
+		{ID: "a", MID: "0", Kind: "audio", SSRCs: []uint32{1}},
+		{MID: "1", Kind: "video"},
+	}}}, streams)
+
+	unified.MediaDescriptions[1].WithValueAttribute(AttrKeyMsid, "")
+	_, err = unified.MediaStreams()
+	assert.ErrorIs(t, err, errInvalidMsid)
+}
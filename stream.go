@@ -0,0 +1,209 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"slices"
+)
+
+const (
+	// minDecoderRead is the minimum free space of the Decoder buffer for a
+	// read.
+	minDecoderRead = 512
+
+	// maxConsecutiveEmptyReads bounds the reads that return no data and no
+	// error, as bufio.Reader does.
+	maxConsecutiveEmptyReads = 100
+)
+
+// A Decoder reads and decodes session descriptions from an input stream.
+type Decoder struct {
+	r         io.Reader
+	delimiter []byte
+	options   UnmarshalOptions
+	warnings  []*ParseError
+
+	buf []byte
+	err error
+}
+
+// NewDecoder returns a new decoder that reads from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: r}
+}
+
+// SetDelimiter sets the text that separates the session descriptions of the
+// stream, for example the framing of a SAP or RTSP transport. By default
+// every "v=" line starts a new session description. A missing line ending
+// of the last line of a session description is added.
+func (d *Decoder) SetDelimiter(delimiter string) {
+	d.delimiter = []byte(delimiter)
+}
+
+// SetOptions sets the options used to unmarshal the session descriptions.
+// Limits.MaxSize also bounds the data buffered while looking for the end of
+// a session description.
+func (d *Decoder) SetOptions(options UnmarshalOptions) {
+	d.options = options
+}
+
+// Warnings returns the warnings of the last call to Decode in lenient mode.
+func (d *Decoder) Warnings() []*ParseError {
+	return d.warnings
+}
+
+// Decode reads the next session description from its input and stores it in
+// the value pointed to by s. Empty session descriptions between delimiters
+// are skipped. At the end of the input, Decode returns io.EOF.
+func (d *Decoder) Decode(s *SessionDescription) error {
+	d.warnings = nil
+
+	document, err := d.next()
+	if err != nil {
+		return err
+	}
+
+	d.warnings, err = s.UnmarshalStringWithOptions(document, d.options)
+
+	return err
+}
+
+// next returns the next non-empty session description of the input.
+func (d *Decoder) next() (string, error) {
+	for {
+		end, advance, ok := d.split()
+		if !ok {
+			if err := checkLimit("MaxSize", d.options.Limits.MaxSize, len(d.buf)); err != nil {
+				return "", err
+			}
+			if d.err != nil {
+				return "", d.err
+			}
+			d.fill()
+
+			continue
+		}
+
+		document := d.buf[:end]
+		d.buf = d.buf[advance:]
+		if len(bytes.TrimSpace(document)) == 0 {
+			continue
+		}
+
+		// The delimiter may include the line ending of the last line.
+		if document[len(document)-1] != '\n' {
+			return string(document) + "\r\n", nil
+		}
+
+		return string(document), nil
+	}
+}
+
+// split finds the end of the first session description in the buffer. The
+// remainder of the buffer is only a complete session description once the
+// input is exhausted.
+func (d *Decoder) split() (end, advance int, ok bool) {
+	if len(d.delimiter) > 0 {
+		if i := bytes.Index(d.buf, d.delimiter); i >= 0 {
+			return i, i + len(d.delimiter), true
+		}
+	} else if len(d.buf) > 0 {
+		// The first line may be the "v=" line of the session description
+		// itself, so the search starts after its first byte.
+		if i := bytes.Index(d.buf[1:], []byte("\nv=")); i >= 0 {
+			return i + 2, i + 2, true
+		}
+	}
+
+	if errors.Is(d.err, io.EOF) && len(d.buf) > 0 {
+		return len(d.buf), len(d.buf), true
+	}
+
+	return 0, 0, false
+}
+
+// fill reads more data into the buffer. A reader that repeatedly returns
+// neither data nor an error fails with io.ErrNoProgress.
+func (d *Decoder) fill() {
+	d.buf = slices.Grow(d.buf, minDecoderRead)
+
+	for range maxConsecutiveEmptyReads {
+		n, err := d.r.Read(d.buf[len(d.buf):cap(d.buf)])
+		d.buf = d.buf[:len(d.buf)+n]
+		d.err = err
+		if n > 0 || err != nil {
+			return
+		}
+	}
+	d.err = io.ErrNoProgress
+}
+
+// An Encoder writes session descriptions to an output stream.
+type Encoder struct {
+	w         io.Writer
+	delimiter string
+	buf       []byte
+}
+
+// NewEncoder returns a new encoder that writes to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// SetDelimiter sets the text written after each session description, see
+// Decoder.SetDelimiter.
+func (e *Encoder) SetDelimiter(delimiter string) {
+	e.delimiter = delimiter
+}
+
+// Encode writes the session description to the stream, followed by the
+// delimiter. The session and each media description are written as they are
+// marshaled, without building the whole session description in memory.
+func (e *Encoder) Encode(s *SessionDescription) error {
+	if err := e.encode(s); err != nil {
+		return err
+	}
+
+	if e.delimiter == "" {
+		return nil
+	}
+
+	_, err := io.WriteString(e.w, e.delimiter)
+
+	return err
+}
+
+func (e *Encoder) encode(s *SessionDescription) error {
+	if s.preserved != nil {
+		marsh, err := s.Marshal()
+		if err != nil {
+			return err
+		}
+
+		return e.write(marsh)
+	}
+
+	e.buf = s.marshalSessionInto(slices.Grow(e.buf[:0], s.marshalSessionSize()))
+	if err := e.write(e.buf); err != nil {
+		return err
+	}
+
+	for _, md := range s.MediaDescriptions {
+		e.buf = md.marshalInto(slices.Grow(e.buf[:0], md.marshalSize()))
+		if err := e.write(e.buf); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *Encoder) write(b []byte) error {
+	_, err := e.w.Write(b)
+
+	return err
+}
@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecoder(t *testing.T) {
+	stream := CanonicalUnmarshalSDP + "\r\n" + BaseSDP + "v=0\r\no=- 1 1 IN IP4 0.0.0.0\r\ns=-\r\nt=0 0\r\n"
+
+	for name, r := range map[string]io.Reader{
+		"whole":    strings.NewReader(stream),
+		"one byte": iotest.OneByteReader(strings.NewReader(stream)),
+	} {
+		t.Run(name, func(t *testing.T) {
+			decoder := NewDecoder(r)
+
+			var first, second, third SessionDescription
+			assert.NoError(t, decoder.Decode(&first))
+			assert.NoError(t, decoder.Decode(&second))
+			assert.NoError(t, decoder.Decode(&third))
+			assert.ErrorIs(t, decoder.Decode(&SessionDescription{}), io.EOF)
+
+			actual, err := first.Marshal()
+			assert.NoError(t, err)
+			assert.Equal(t, CanonicalUnmarshalSDP, string(actual))
+
+			actual, err = second.Marshal()
+			assert.NoError(t, err)
+			assert.Equal(t, BaseSDP, string(actual))
+			assert.Equal(t, uint64(1), third.Origin.SessionID)
+		})
+	}
+}
+
+func TestDecoder_Delimiter(t *testing.T) {
+	decoder := NewDecoder(iotest.HalfReader(strings.NewReader(
+		"--frame\r\n" + BaseSDP + "--frame\r\n--frame\r\n" + BaseSDP + "x=invalid\r\n--frame\r\n",
+	)))
+	decoder.SetDelimiter("--frame\r\n")
+
+	var sd SessionDescription
+	assert.NoError(t, decoder.Decode(&sd))
+	assert.Equal(t, "SDP Seminar", string(sd.SessionName))
+
+	assert.ErrorIs(t, decoder.Decode(&sd), ErrSDPInvalidSyntax)
+	assert.ErrorIs(t, decoder.Decode(&sd), io.EOF)
+}
+
+func TestDecoder_Options(t *testing.T) {
+	decoder := NewDecoder(strings.NewReader(BaseSDP + "x=unknown\r\n"))
+	decoder.SetOptions(UnmarshalOptions{Lenient: true})
+
+	var sd SessionDescription
+	assert.NoError(t, decoder.Decode(&sd))
+	assert.Len(t, decoder.Warnings(), 1)
+	assert.ErrorIs(t, decoder.Decode(&sd), io.EOF)
+	assert.Empty(t, decoder.Warnings())
+}
+
+func TestDecoder_MaxSize(t *testing.T) {
+	decoder := NewDecoder(iotest.OneByteReader(strings.NewReader(BaseSDP + strings.Repeat("a=x\r\n", 1000))))
+	decoder.SetDelimiter("\r\n\r\n")
+	decoder.SetOptions(UnmarshalOptions{Limits: Limits{MaxSize: 1000}})
+
+	var limitErr *LimitError
+	assert.ErrorAs(t, decoder.Decode(&SessionDescription{}), &limitErr)
+}
+
+func TestDecoder_ReadError(t *testing.T) {
+	errRead := errors.New("read failed")
+	decoder := NewDecoder(io.MultiReader(strings.NewReader(BaseSDP), iotest.ErrReader(errRead)))
+
+	assert.ErrorIs(t, decoder.Decode(&SessionDescription{}), errRead)
+}
+
+type noProgressReader struct{}
+
+func (noProgressReader) Read([]byte) (int, error) {
+	return 0, nil
+}
+
+func TestDecoder_NoProgress(t *testing.T) {
+	decoder := NewDecoder(noProgressReader{})
+	assert.ErrorIs(t, decoder.Decode(&SessionDescription{}), io.ErrNoProgress)
+
+	decoder = NewDecoder(io.MultiReader(strings.NewReader(BaseSDP), noProgressReader{}))
+	assert.ErrorIs(t, decoder.Decode(&SessionDescription{}), io.ErrNoProgress)
+}
+
+func TestEncoder(t *testing.T) {
+	var first, second SessionDescription
+	assert.NoError(t, first.UnmarshalString(CanonicalUnmarshalSDP))
+	assert.NoError(t, second.UnmarshalString(BaseSDP))
+
+	var buf bytes.Buffer
+	encoder := NewEncoder(&buf)
+	encoder.SetDelimiter("\r\n")
+	assert.NoError(t, encoder.Encode(&first))
+	assert.NoError(t, encoder.Encode(&second))
+	assert.Equal(t, CanonicalUnmarshalSDP+"\r\n"+BaseSDP+"\r\n", buf.String())
+
+	decoder := NewDecoder(&buf)
+	decoder.SetDelimiter("\r\n\r\n")
+	var decoded SessionDescription
+	assert.NoError(t, decoder.Decode(&decoded))
+	assert.Equal(t, first, decoded)
+}
+
+func TestEncoder_Preserve(t *testing.T) {
+	var sd SessionDescription
+	_, err := sd.UnmarshalStringWithOptions(preserveSDP, UnmarshalOptions{Preserve: true})
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	assert.NoError(t, NewEncoder(&buf).Encode(&sd))
+	assert.Equal(t, preserveSDP, buf.String())
+}
+
+type failingWriter struct{ writes int }
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.writes == 0 {
+		return 0, io.ErrShortWrite
+	}
+	w.writes--
+
+	return len(p), nil
+}
+
+func TestEncoder_WriteError(t *testing.T) {
+	var sd SessionDescription
+	assert.NoError(t, sd.UnmarshalString(CanonicalUnmarshalSDP))
+
+	for writes := range 3 {
+		encoder := NewEncoder(&failingWriter{writes: writes})
+		encoder.SetDelimiter("\r\n")
+		assert.ErrorIs(t, encoder.Encode(&sd), io.ErrShortWrite)
+	}
+}
@@ -0,0 +1,222 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The field types implement encoding.TextMarshaler and
+// encoding.TextUnmarshaler with the value of their line, without the
+// "<type>=" prefix. UnmarshalText uses the parsers of UnmarshalString and
+// accepts exactly one line value.
+
+// unmarshalText parses text as the value of a single line with read, which
+// must consume the whole value.
+func unmarshalText(text []byte, read func(*lexer) error) error {
+	value := string(text)
+	if strings.ContainsAny(value, "\r\n") {
+		return fmt.Errorf("%w `%v`", ErrSDPInvalidSyntax, value)
+	}
+
+	lex := &lexer{}
+	lex.value = value + "\r\n"
+	if err := read(lex); err != nil {
+		return err
+	}
+
+	if err := lex.nextLine(); err != nil {
+		return err
+	}
+	if lex.pos != len(lex.value) {
+		return syntaxError{s: lex.value, i: lex.pos}
+	}
+
+	return nil
+}
+
+// unmarshalLineText returns text as the value of a single line.
+func unmarshalLineText(text []byte) (string, error) {
+	value := string(text)
+	if value == "" || strings.ContainsAny(value, "\r\n") {
+		return "", fmt.Errorf("%w `%v`", ErrSDPInvalidSyntax, value)
+	}
+
+	return value, nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (o Origin) MarshalText() ([]byte, error) {
+	return o.marshalInto(make([]byte, 0, o.marshalSize())), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (o *Origin) UnmarshalText(text []byte) error {
+	return unmarshalText(text, func(l *lexer) (err error) {
+		*o, err = l.readOrigin()
+
+		return err
+	})
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (c ConnectionInformation) MarshalText() ([]byte, error) {
+	return c.marshalInto(make([]byte, 0, c.marshalSize())), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *ConnectionInformation) UnmarshalText(text []byte) error {
+	return unmarshalText(text, func(l *lexer) error {
+		connectionInformation, err := l.unmarshalConnectionInformation()
+		if err != nil {
+			return err
+		}
+		*c = *connectionInformation
+
+		return nil
+	})
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (b Bandwidth) MarshalText() ([]byte, error) {
+	return b.marshalInto(make([]byte, 0, b.marshalSize())), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (b *Bandwidth) UnmarshalText(text []byte) error {
+	value, err := unmarshalLineText(text)
+	if err != nil {
+		return err
+	}
+
+	bandwidth, err := unmarshalBandwidth(value)
+	if err != nil {
+		return err
+	}
+	*b = *bandwidth
+
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (m MediaName) MarshalText() ([]byte, error) {
+	return m.marshalInto(make([]byte, 0, m.marshalSize())), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (m *MediaName) UnmarshalText(text []byte) error {
+	return unmarshalText(text, func(l *lexer) (err error) {
+		*m, err = l.readMediaName()
+
+		return err
+	})
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t Timing) MarshalText() ([]byte, error) {
+	return t.marshalInto(make([]byte, 0, t.marshalSize())), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *Timing) UnmarshalText(text []byte) error {
+	return unmarshalText(text, func(l *lexer) (err error) {
+		*t, err = l.readTiming()
+
+		return err
+	})
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (r RepeatTime) MarshalText() ([]byte, error) {
+	return r.marshalInto(make([]byte, 0, r.marshalSize())), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The interval, duration
+// and offsets may use the d, h, m and s shorthands.
+func (r *RepeatTime) UnmarshalText(text []byte) error {
+	return unmarshalText(text, func(l *lexer) (err error) {
+		*r, err = l.readRepeatTime()
+
+		return err
+	})
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (z TimeZone) MarshalText() ([]byte, error) {
+	return z.marshalInto(make([]byte, 0, z.marshalSize())), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The text holds a single
+// adjustment time and offset pair of a "z=" line.
+func (z *TimeZone) UnmarshalText(text []byte) error {
+	return unmarshalText(text, func(l *lexer) error {
+		timeZones, err := l.readTimeZones()
+		if err != nil {
+			return err
+		}
+		if len(timeZones) != 1 {
+			return fmt.Errorf("%w `%v`", ErrSDPInvalidValue, string(text))
+		}
+		*z = timeZones[0]
+
+		return nil
+	})
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (a Attribute) MarshalText() ([]byte, error) {
+	return a.marshalInto(make([]byte, 0, a.marshalSize())), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (a *Attribute) UnmarshalText(text []byte) error {
+	value, err := unmarshalLineText(text)
+	if err != nil {
+		return err
+	}
+	*a = unmarshalAttribute(value)
+
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t Direction) MarshalText() ([]byte, error) {
+	if t.String() == directionUnknownStr {
+		return nil, fmt.Errorf("%w: %d", errDirectionString, int(t))
+	}
+
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *Direction) UnmarshalText(text []byte) error {
+	direction, err := NewDirection(string(text))
+	if err != nil {
+		return err
+	}
+	*t = direction
+
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t ConnectionRole) MarshalText() ([]byte, error) {
+	if _, err := NewConnectionRole(t.String()); err != nil {
+		return nil, err
+	}
+
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *ConnectionRole) UnmarshalText(text []byte) error {
+	role, err := NewConnectionRole(string(text))
+	if err != nil {
+		return err
+	}
+	*t = role
+
+	return nil
+}
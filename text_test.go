@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"encoding"
+	"encoding/json"
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestText(t *testing.T) {
+	for _, test := range []struct {
+		text  string
+		value interface {
+			encoding.TextMarshaler
+			encoding.TextUnmarshaler
+		}
+	}{
+		{"jdoe 2890844526 2890842807 IN IP4 10.47.16.5", &Origin{}},
+		{"IN IP6 ff15::103/3", &ConnectionInformation{}},
+		{"X-YZ:128", &Bandwidth{}},
+		{"AS:64", &Bandwidth{}},
+		{"video 49170/2 RTP/AVP 31 32", &MediaName{}},
+		{"2873397496 2873404696", &Timing{}},
+		{"0 0", &Timing{}},
+		{"604800 3600 0 90000", &RepeatTime{}},
+		{"2882844526 -3600", &TimeZone{}},
+		{"rtpmap:96 VP8/90000", &Attribute{}},
+		{"rtcp-mux", &Attribute{}},
+		{"sendonly", new(Direction)},
+		{"actpass", new(ConnectionRole)},
+	} {
+		assert.NoError(t, test.value.UnmarshalText([]byte(test.text)), test.text)
+		text, err := test.value.MarshalText()
+		assert.NoError(t, err)
+		assert.Equal(t, test.text, string(text))
+	}
+}
+
+func TestText_Values(t *testing.T) {
+	var repeatTime RepeatTime
+	assert.NoError(t, repeatTime.UnmarshalText([]byte("7d 1h 0 25h")))
+	assert.Equal(t, RepeatTime{Interval: 604800, Duration: 3600, Offsets: []int64{0, 90000}}, repeatTime)
+
+	var origin Origin
+	assert.NoError(t, origin.UnmarshalText([]byte("- 1 2 IN")))
+	assert.Equal(t, Origin{
+		Username: "-", SessionID: 1, SessionVersion: 2,
+		NetworkType: "IN", AddressType: "IP4", UnicastAddress: "0.0.0.0",
+	}, origin)
+
+	var attribute Attribute
+	assert.NoError(t, attribute.UnmarshalText([]byte("fmtp:96 apt=100")))
+	assert.Equal(t, NewAttribute("fmtp", "96 apt=100"), attribute)
+
+	var mediaName MediaName
+	assert.NoError(t, mediaName.UnmarshalText([]byte("audio 9 UDP/TLS/RTP/SAVPF 111")))
+	assert.Equal(t, []string{"UDP", "TLS", "RTP", "SAVPF"}, mediaName.Protos)
+}
+
+func TestText_Errors(t *testing.T) {
+	var origin Origin
+	assert.ErrorIs(t, origin.UnmarshalText([]byte("- 1 2 IN IP4 127.0.0.1 extra")), ErrSDPInvalidSyntax)
+	assert.ErrorIs(t, origin.UnmarshalText([]byte("- 1 2 IN IP4 127.0.0.1\r\n")), ErrSDPInvalidSyntax)
+	assert.ErrorIs(t, origin.UnmarshalText([]byte("- x 2 IN IP4 127.0.0.1")), ErrSDPInvalidSyntax)
+	assert.ErrorIs(t, origin.UnmarshalText([]byte("- 1 2 XX IP4 127.0.0.1")), ErrSDPInvalidValue)
+
+	var connectionInformation ConnectionInformation
+	assert.ErrorIs(t, connectionInformation.UnmarshalText([]byte("IN IP7 ::1")), ErrSDPInvalidValue)
+
+	var bandwidth Bandwidth
+	assert.ErrorIs(t, bandwidth.UnmarshalText([]byte("XX:1")), ErrSDPInvalidValue)
+	assert.ErrorIs(t, bandwidth.UnmarshalText([]byte("")), ErrSDPInvalidSyntax)
+
+	var mediaName MediaName
+	assert.ErrorIs(t, mediaName.UnmarshalText([]byte("audio 65536 RTP/AVP 0")), ErrSDPInvalidPortValue)
+
+	var timing Timing
+	assert.ErrorIs(t, timing.UnmarshalText([]byte("0 0 0")), ErrSDPInvalidSyntax)
+
+	var repeatTime RepeatTime
+	assert.ErrorIs(t, repeatTime.UnmarshalText([]byte("1w 1h")), ErrSDPInvalidValue)
+
+	var timeZone TimeZone
+	assert.ErrorIs(t, timeZone.UnmarshalText([]byte("2882844526 -1h 2898848070 0")), ErrSDPInvalidValue)
+	assert.ErrorIs(t, timeZone.UnmarshalText([]byte("2882844526")), ErrSDPInvalidValue)
+
+	var attribute Attribute
+	assert.ErrorIs(t, attribute.UnmarshalText([]byte("rtcp-mux\nrecvonly")), ErrSDPInvalidSyntax)
+
+	var direction Direction
+	assert.ErrorIs(t, direction.UnmarshalText([]byte("sendrecv ")), errDirectionString)
+	_, err := direction.MarshalText()
+	assert.ErrorIs(t, err, errDirectionString)
+
+	var role ConnectionRole
+	assert.ErrorIs(t, role.UnmarshalText([]byte("Unknown")), errConnectionRole)
+	_, err = role.MarshalText()
+	assert.ErrorIs(t, err, errConnectionRole)
+}
+
+func TestText_JSONAndFlag(t *testing.T) {
+	var config struct {
+		Bandwidth []Bandwidth `json:"bandwidth"`
+		Direction Direction   `json:"direction"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(`{"bandwidth":["AS:64","TIAS:64000"],"direction":"recvonly"}`), &config))
+	assert.Equal(t, []Bandwidth{{Type: "AS", Bandwidth: 64}, {Type: "TIAS", Bandwidth: 64000}}, config.Bandwidth)
+	assert.Equal(t, DirectionRecvOnly, config.Direction)
+
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	var origin Origin
+	flags.TextVar(&origin, "origin", Origin{Username: "-", NetworkType: "IN", AddressType: "IP4",
+		UnicastAddress: "127.0.0.1"}, "origin line")
+	assert.NoError(t, flags.Parse([]string{"-origin", "alice 1 1 IN IP6 ::1"}))
+	assert.Equal(t, "alice 1 1 IN IP6 ::1", origin.String())
+}
@@ -12,12 +12,22 @@ import (
 	"sync"
 )
 
+// Causes of a ParseError, to be matched with errors.Is.
 var (
-	errSDPInvalidSyntax       = errors.New("sdp: invalid syntax")
-	errSDPInvalidNumericValue = errors.New("sdp: invalid numeric value")
-	errSDPInvalidValue        = errors.New("sdp: invalid value")
-	errSDPInvalidPortValue    = errors.New("sdp: invalid port value")
-	errSDPCacheInvalid        = errors.New("sdp: invalid cache")
+	// ErrSDPInvalidSyntax is returned for malformed or out of order lines.
+	ErrSDPInvalidSyntax = errors.New("sdp: invalid syntax")
+	// ErrSDPInvalidNumericValue is returned for fields that must be numeric.
+	ErrSDPInvalidNumericValue = errors.New("sdp: invalid numeric value")
+	// ErrSDPInvalidValue is returned for values that are not allowed.
+	ErrSDPInvalidValue = errors.New("sdp: invalid value")
+	// ErrSDPInvalidPortValue is returned for ports that are not valid.
+	ErrSDPInvalidPortValue = errors.New("sdp: invalid port value")
+	// ErrSDPUnexpectedEOF is returned when the description ends within a line.
+	ErrSDPUnexpectedEOF = errors.New("sdp: unexpected end of description")
+)
+
+var (
+	errSDPCacheInvalid = errors.New("sdp: invalid cache")
 
 	//nolint: gochecknoglobals
 	unmarshalCachePool = sync.Pool{
@@ -109,29 +119,9 @@ var (
 // |   s16  |    |    14 |    |     |    |  15 |   |    | 12 |   |   |     |   |   |    |   |    |
 // +--------+----+-------+----+-----+----+-----+---+----+----+---+---+-----+---+---+----+---+----+ .
 func (s *SessionDescription) UnmarshalString(value string) error {
-	var ok bool
-	lex := new(lexer)
-	if lex.cache, ok = unmarshalCachePool.Get().(*unmarshalCache); !ok {
-		return errSDPCacheInvalid
-	}
-	defer unmarshalCachePool.Put(lex.cache)
-
-	lex.cache.reset()
-	lex.desc = s
-	lex.value = value
+	_, err := s.UnmarshalStringWithOptions(value, UnmarshalOptions{})
 
-	for state := s1; state != nil; {
-		var err error
-		state, err = state(lex)
-		if err != nil {
-			return err
-		}
-	}
-
-	s.Attributes = lex.cache.cloneSessionAttributes()
-	populateMediaAttributes(lex.cache, lex.desc)
-
-	return nil
+	return err
 }
 
 // Unmarshal converts the value into a []byte and then calls UnmarshalString.
@@ -426,7 +416,7 @@ func unmarshalProtocolVersion(l *lexer) (stateFn, error) {
 	// As off the latest draft of the rfc this value is required to be 0.
 	// https://tools.ietf.org/html/draft-ietf-rtcweb-jsep-24#section-5.8.1
 	if version != 0 {
-		return nil, fmt.Errorf("%w `%v`", errSDPInvalidValue, version)
+		return nil, fmt.Errorf("%w `%v`", ErrSDPInvalidValue, version)
 	}
 
 	if err := l.nextLine(); err != nil {
@@ -438,66 +428,76 @@ func unmarshalProtocolVersion(l *lexer) (stateFn, error) {
 
 func unmarshalOrigin(lex *lexer) (stateFn, error) {
 	var err error
-
-	lex.desc.Origin.Username, err = lex.readField()
+	lex.desc.Origin, err = lex.readOrigin()
 	if err != nil {
 		return nil, err
 	}
 
-	lex.desc.Origin.SessionID, err = lex.readUint64Field()
-	if err != nil {
+	if err := lex.nextLine(); err != nil {
 		return nil, err
 	}
 
-	lex.desc.Origin.SessionVersion, err = lex.readUint64Field()
+	return s3, nil
+}
+
+func (l *lexer) readOrigin() (Origin, error) {
+	var err error
+	var origin Origin
+
+	origin.Username, err = l.readField()
 	if err != nil {
-		return nil, err
+		return origin, err
 	}
 
-	lex.desc.Origin.NetworkType, err = lex.readField()
+	origin.SessionID, err = l.readUint64Field()
 	if err != nil {
-		return nil, err
+		return origin, err
+	}
+
+	origin.SessionVersion, err = l.readUint64Field()
+	if err != nil {
+		return origin, err
+	}
+
+	origin.NetworkType, err = l.readField()
+	if err != nil {
+		return origin, err
 	}
 
 	// Set according to currently registered with IANA
 	// https://tools.ietf.org/html/rfc4566#section-8.2.6
-	if !anyOf(lex.desc.Origin.NetworkType, "IN") {
-		return nil, fmt.Errorf("%w `%v`", errSDPInvalidValue, lex.desc.Origin.NetworkType)
+	err = l.checkToken(origin.NetworkType, ErrSDPInvalidValue, l.options.NetworkTypes, "IN")
+	if err != nil {
+		return origin, err
 	}
 
 	// Handle potentially missing AddressType field
-	err = handleAddressType(lex)
+	err = handleAddressType(l, &origin)
 	if err != nil {
-		return nil, err
+		return origin, err
 	}
 
 	// Set according to currently registered with IANA
 	// https://tools.ietf.org/html/rfc4566#section-8.2.7
-	if !anyOf(lex.desc.Origin.AddressType, "IP4", "IP6") {
-		return nil, fmt.Errorf("%w `%v`", errSDPInvalidValue, lex.desc.Origin.AddressType)
-	}
-
-	// Handle potentially missing UnicastAddress field
-	err = handleUnicastAddress(lex)
+	err = l.checkToken(origin.AddressType, ErrSDPInvalidValue, l.options.AddressTypes, "IP4", "IP6")
 	if err != nil {
-		return nil, err
+		return origin, err
 	}
 
-	if err := lex.nextLine(); err != nil {
-		return nil, err
-	}
+	// Handle potentially missing UnicastAddress field
+	err = handleUnicastAddress(l, &origin)
 
-	return s3, nil
+	return origin, err
 }
 
 // handleAddressType processes AddressType field with graceful handling for missing fields.
-func handleAddressType(lex *lexer) error {
+func handleAddressType(lex *lexer, origin *Origin) error {
 	addressType, err := lex.readRequiredField()
 	if err != nil {
 		if errors.Is(err, errFieldMissing) {
 			// Field missing - use defaults for camera compatibility
-			lex.desc.Origin.AddressType = "IP4"
-			lex.desc.Origin.UnicastAddress = "0.0.0.0"
+			origin.AddressType = "IP4"
+			origin.UnicastAddress = "0.0.0.0"
 
 			return nil
 		}
@@ -505,21 +505,21 @@ func handleAddressType(lex *lexer) error {
 		return err
 	}
 
-	lex.desc.Origin.AddressType = addressType
+	origin.AddressType = addressType
 
 	return nil
 }
 
 // handleUnicastAddress processes UnicastAddress field with graceful handling for missing fields.
-func handleUnicastAddress(lex *lexer) error {
+func handleUnicastAddress(lex *lexer, origin *Origin) error {
 	unicastAddress, err := lex.readRequiredField()
 	if err != nil {
 		if errors.Is(err, errFieldMissing) {
 			// Use appropriate default based on address type
-			if lex.desc.Origin.AddressType == "IP6" {
-				lex.desc.Origin.UnicastAddress = "::"
+			if origin.AddressType == "IP6" {
+				origin.UnicastAddress = "::"
 			} else {
-				lex.desc.Origin.UnicastAddress = "0.0.0.0"
+				origin.UnicastAddress = "0.0.0.0"
 			}
 
 			return nil
@@ -528,7 +528,7 @@ func handleUnicastAddress(lex *lexer) error {
 		return err
 	}
 
-	lex.desc.Origin.UnicastAddress = unicastAddress
+	origin.UnicastAddress = unicastAddress
 
 	return nil
 }
@@ -564,7 +564,7 @@ func unmarshalURI(l *lexer) (stateFn, error) {
 
 	l.desc.URI, err = url.Parse(value)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w `%v`: %w", ErrSDPInvalidValue, value, err)
 	}
 
 	return s10, nil
@@ -615,8 +615,9 @@ func (l *lexer) unmarshalConnectionInformation() (*ConnectionInformation, error)
 
 	// Set according to currently registered with IANA
 	// https://tools.ietf.org/html/rfc4566#section-8.2.6
-	if !anyOf(connInfo.NetworkType, "IN") {
-		return nil, fmt.Errorf("%w `%v`", errSDPInvalidValue, connInfo.NetworkType)
+	err = l.checkToken(connInfo.NetworkType, ErrSDPInvalidValue, l.options.NetworkTypes, "IN")
+	if err != nil {
+		return nil, err
 	}
 
 	connInfo.AddressType, err = l.readField()
@@ -626,8 +627,9 @@ func (l *lexer) unmarshalConnectionInformation() (*ConnectionInformation, error)
 
 	// Set according to currently registered with IANA
 	// https://tools.ietf.org/html/rfc4566#section-8.2.7
-	if !anyOf(connInfo.AddressType, "IP4", "IP6") {
-		return nil, fmt.Errorf("%w `%v`", errSDPInvalidValue, connInfo.AddressType)
+	err = l.checkToken(connInfo.AddressType, ErrSDPInvalidValue, l.options.AddressTypes, "IP4", "IP6")
+	if err != nil {
+		return nil, err
 	}
 
 	address, err := l.readField()
@@ -635,7 +637,12 @@ func (l *lexer) unmarshalConnectionInformation() (*ConnectionInformation, error)
 		return nil, err
 	}
 
-	if address != "" {
+	if address != "" && l.rfc8866() {
+		connInfo.Address, err = parseConnectionAddress(connInfo.AddressType, address)
+		if err != nil {
+			return nil, err
+		}
+	} else if address != "" {
 		connInfo.Address = new(Address)
 		connInfo.Address.Address = address
 	}
@@ -653,35 +660,55 @@ func unmarshalSessionBandwidth(l *lexer) (stateFn, error) {
 		return nil, err
 	}
 
-	bandwidth, err := unmarshalBandwidth(value)
+	bandwidth, err := l.unmarshalBandwidth(value)
 	if err != nil {
-		return nil, fmt.Errorf("%w `b=%v`", errSDPInvalidValue, value)
+		return nil, fmt.Errorf("%w `b=%v`", ErrSDPInvalidValue, value)
 	}
 	l.desc.Bandwidth = append(l.desc.Bandwidth, *bandwidth)
 
 	return s5, nil
 }
 
+// unmarshalBandwidth parses a b= value. RFC 8866 allows any token as
+// bandwidth type, which is ignored by receivers that do not understand it.
+// https://datatracker.ietf.org/doc/html/rfc8866#section-5.8
+func (l *lexer) unmarshalBandwidth(value string) (*Bandwidth, error) {
+	if !l.rfc8866() {
+		return unmarshalBandwidth(value)
+	}
+
+	bandwidthType, _, _ := strings.Cut(value, ":")
+	if !isToken(bandwidthType) {
+		return nil, fmt.Errorf("%w `%v`", ErrSDPInvalidValue, bandwidthType)
+	}
+
+	return unmarshalBandwidthType(value, true)
+}
+
 func unmarshalBandwidth(value string) (*Bandwidth, error) {
+	return unmarshalBandwidthType(value, false)
+}
+
+func unmarshalBandwidthType(value string, anyType bool) (*Bandwidth, error) {
 	parts := strings.Split(value, ":")
 	if len(parts) != 2 {
-		return nil, fmt.Errorf("%w `b=%v`", errSDPInvalidValue, parts)
+		return nil, fmt.Errorf("%w `b=%v`", ErrSDPInvalidValue, parts)
 	}
 
 	experimental := strings.HasPrefix(parts[0], "X-")
 	if experimental {
 		parts[0] = strings.TrimPrefix(parts[0], "X-")
-	} else if !anyOf(parts[0], "CT", "AS", "TIAS", "RS", "RR") {
+	} else if !anyType && !anyOf(parts[0], "CT", "AS", "TIAS", "RS", "RR") {
 		// Set according to currently registered with IANA
 		// https://tools.ietf.org/html/rfc4566#section-5.8
 		// https://tools.ietf.org/html/rfc3890#section-6.2
 		// https://tools.ietf.org/html/rfc3556#section-2
-		return nil, fmt.Errorf("%w `%v`", errSDPInvalidValue, parts[0])
+		return nil, fmt.Errorf("%w `%v`", ErrSDPInvalidValue, parts[0])
 	}
 
 	bandwidth, err := strconv.ParseUint(parts[1], 10, 64)
 	if err != nil {
-		return nil, fmt.Errorf("%w `%v`", errSDPInvalidNumericValue, parts[1])
+		return nil, fmt.Errorf("%w `%v`", ErrSDPInvalidNumericValue, parts[1])
 	}
 
 	return &Bandwidth{
@@ -695,12 +722,7 @@ func unmarshalTiming(lex *lexer) (stateFn, error) {
 	var err error
 	var td TimeDescription
 
-	td.Timing.StartTime, err = lex.readUint64Field()
-	if err != nil {
-		return nil, err
-	}
-
-	td.Timing.StopTime, err = lex.readUint64Field()
+	td.Timing, err = lex.readTiming()
 	if err != nil {
 		return nil, err
 	}
@@ -714,91 +736,142 @@ func unmarshalTiming(lex *lexer) (stateFn, error) {
 	return s9, nil
 }
 
+func (l *lexer) readTiming() (Timing, error) {
+	var err error
+	var timing Timing
+
+	timing.StartTime, err = l.readUint64Field()
+	if err != nil {
+		return timing, err
+	}
+
+	timing.StopTime, err = l.readUint64Field()
+
+	return timing, err
+}
+
 func unmarshalRepeatTimes(lex *lexer) (stateFn, error) {
 	var err error
 	var newRepeatTime RepeatTime
 
 	latestTimeDesc := &lex.desc.TimeDescriptions[len(lex.desc.TimeDescriptions)-1]
+	err = checkLimit("MaxRepeatTimes", lex.options.Limits.MaxRepeatTimes, len(latestTimeDesc.RepeatTimes)+1)
+	if err != nil {
+		return nil, err
+	}
 
-	field, err := lex.readField()
+	newRepeatTime, err = lex.readRepeatTime()
 	if err != nil {
 		return nil, err
 	}
 
-	newRepeatTime.Interval, err = parseTimeUnits(field)
+	if err := lex.nextLine(); err != nil {
+		return nil, err
+	}
+
+	latestTimeDesc.RepeatTimes = append(latestTimeDesc.RepeatTimes, newRepeatTime)
+
+	return s9, nil
+}
+
+func (l *lexer) readRepeatTime() (RepeatTime, error) {
+	var repeatTime RepeatTime
+
+	field, err := l.readField()
 	if err != nil {
-		return nil, fmt.Errorf("%w `%v`", errSDPInvalidValue, field)
+		return repeatTime, err
 	}
 
-	field, err = lex.readField()
+	repeatTime.Interval, err = parseTimeUnits(field)
 	if err != nil {
-		return nil, err
+		return repeatTime, fmt.Errorf("%w `%v`", ErrSDPInvalidValue, field)
 	}
 
-	newRepeatTime.Duration, err = parseTimeUnits(field)
+	field, err = l.readField()
 	if err != nil {
-		return nil, fmt.Errorf("%w `%v`", errSDPInvalidValue, field)
+		return repeatTime, err
+	}
+
+	repeatTime.Duration, err = parseTimeUnits(field)
+	if err != nil {
+		return repeatTime, fmt.Errorf("%w `%v`", ErrSDPInvalidValue, field)
 	}
 
 	for {
-		field, err := lex.readField()
+		field, err := l.readField()
 		if err != nil {
-			return nil, err
+			return repeatTime, err
 		}
 		if field == "" {
 			break
 		}
 		offset, err := parseTimeUnits(field)
 		if err != nil {
-			return nil, fmt.Errorf("%w `%v`", errSDPInvalidValue, field)
+			return repeatTime, fmt.Errorf("%w `%v`", ErrSDPInvalidValue, field)
 		}
-		newRepeatTime.Offsets = append(newRepeatTime.Offsets, offset)
+		repeatTime.Offsets = append(repeatTime.Offsets, offset)
 	}
 
-	if err := lex.nextLine(); err != nil {
+	return repeatTime, nil
+}
+
+func unmarshalTimeZones(lex *lexer) (stateFn, error) {
+	var err error
+	lex.desc.TimeZones, err = lex.readTimeZones()
+	if err != nil {
 		return nil, err
 	}
 
-	latestTimeDesc.RepeatTimes = append(latestTimeDesc.RepeatTimes, newRepeatTime)
+	if err := lex.nextLine(); err != nil {
+		return nil, err
+	}
 
-	return s9, nil
+	return s13, nil
 }
 
-func unmarshalTimeZones(lex *lexer) (stateFn, error) {
+func (l *lexer) readTimeZones() ([]TimeZone, error) {
 	// These fields are transimitted in pairs
 	// z=<adjustment time> <offset> <adjustment time> <offset> ....
 	// so we are making sure that there are actually multiple of 2 total.
+	var timeZones []TimeZone
 	for {
 		var err error
 		var timeZone TimeZone
 
-		timeZone.AdjustmentTime, err = lex.readUint64Field()
+		start := l.pos
+		timeZone.AdjustmentTime, err = l.readUint64Field()
 		if err != nil {
 			return nil, err
 		}
 
-		offset, err := lex.readField()
+		offset, err := l.readField()
 		if err != nil {
 			return nil, err
 		}
 
 		if offset == "" {
+			// RFC 8866 requires at least one complete pair.
+			// https://datatracker.ietf.org/doc/html/rfc8866#section-5.11
+			if l.rfc8866() && (l.fieldStart > start || len(timeZones) == 0) {
+				return nil, fmt.Errorf("%w `z=`: missing offset", ErrSDPInvalidValue)
+			}
+
 			break
 		}
 
+		if l.rfc8866() && strings.HasPrefix(offset, "+") {
+			return nil, fmt.Errorf("%w `%v`", ErrSDPInvalidValue, offset)
+		}
+
 		timeZone.Offset, err = parseTimeUnits(offset)
 		if err != nil {
 			return nil, err
 		}
 
-		lex.desc.TimeZones = append(lex.desc.TimeZones, timeZone)
+		timeZones = append(timeZones, timeZone)
 	}
 
-	if err := lex.nextLine(); err != nil {
-		return nil, err
-	}
-
-	return s13, nil
+	return timeZones, nil
 }
 
 func unmarshalSessionEncryptionKey(l *lexer) (stateFn, error) {
@@ -819,67 +892,115 @@ func unmarshalSessionAttribute(l *lexer) (stateFn, error) {
 		return nil, err
 	}
 
-	i := strings.IndexRune(value, ':')
-	a := l.cache.getSessionAttribute()
-	if i > 0 {
-		a.Key = value[:i]
-		a.Value = value[i+1:]
-	} else {
-		a.Key = value
+	err = l.checkAttributeLimits(len(l.cache.sessionAttributes)+1, value)
+	if err != nil {
+		return nil, err
+	}
+
+	attribute := unmarshalAttribute(value)
+	if l.rfc8866() {
+		if err := checkAttribute(attribute); err != nil {
+			return nil, err
+		}
 	}
+	*l.cache.getSessionAttribute() = attribute
 
 	return s11, nil
 }
 
-func unmarshalMediaDescription(lex *lexer) (stateFn, error) { //nolint:cyclop
+func unmarshalAttribute(value string) Attribute {
+	if i := strings.IndexRune(value, ':'); i > 0 {
+		return Attribute{Key: value[:i], Value: value[i+1:]}
+	}
+
+	return Attribute{Key: value}
+}
+
+func unmarshalMediaDescription(lex *lexer) (stateFn, error) {
+	err := checkLimit(
+		"MaxMediaDescriptions",
+		lex.options.Limits.MaxMediaDescriptions,
+		len(lex.desc.MediaDescriptions)+1,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	populateMediaAttributes(lex.cache, lex.desc)
 	var newMediaDesc MediaDescription
 
-	// <media>
-	field, err := lex.readField()
+	newMediaDesc.MediaName, err = lex.readMediaName()
 	if err != nil {
 		return nil, err
 	}
 
+	if err := lex.nextLine(); err != nil {
+		return nil, err
+	}
+
+	lex.desc.MediaDescriptions = append(lex.desc.MediaDescriptions, &newMediaDesc)
+
+	return s12, nil
+}
+
+func (l *lexer) readMediaName() (MediaName, error) { //nolint:cyclop
+	var mediaName MediaName
+
+	// <media>
+	field, err := l.readField()
+	if err != nil {
+		return mediaName, err
+	}
+
 	// Set according to currently registered with IANA
 	// https://tools.ietf.org/html/rfc4566#section-5.14
-	if !anyOf(field, "audio", "video", "text", "application", "message") {
-		return nil, fmt.Errorf("%w `%v`", errSDPInvalidValue, field)
+	// RFC 8866 allows any token.
+	// https://datatracker.ietf.org/doc/html/rfc8866#section-5.14
+	if l.rfc8866() {
+		err = checkTokenSyntax(field)
+	} else {
+		err = l.checkToken(field, ErrSDPInvalidValue, l.options.MediaTypes,
+			"audio", "video", "text", "application", "message")
+	}
+	if err != nil {
+		return mediaName, err
 	}
-	newMediaDesc.MediaName.Media = field
+	mediaName.Media = field
 
 	// <port>
-	field, err = lex.readField()
+	field, err = l.readField()
 	if err != nil {
-		return nil, err
+		return mediaName, err
 	}
 	parts := strings.Split(field, "/")
-	newMediaDesc.MediaName.Port.Value, err = parsePort(parts[0])
+	mediaName.Port.Value, err = parsePort(parts[0])
 	if err != nil {
-		return nil, fmt.Errorf("%w `%v`", errSDPInvalidPortValue, parts[0])
+		return mediaName, fmt.Errorf("%w `%v`", ErrSDPInvalidPortValue, parts[0])
 	}
 
 	if len(parts) > 1 {
 		var portRange int
 		portRange, err = strconv.Atoi(parts[1])
 		if err != nil {
-			return nil, fmt.Errorf("%w `%v`", errSDPInvalidValue, parts)
+			return mediaName, fmt.Errorf("%w `%v`", ErrSDPInvalidValue, parts)
 		}
-		newMediaDesc.MediaName.Port.Range = &portRange
+		mediaName.Port.Range = &portRange
 	}
 
 	// <proto>
-	field, err = lex.readField()
+	field, err = l.readField()
 	if err != nil {
-		return nil, err
+		return mediaName, err
 	}
 
 	// Set according to currently registered with IANA
 	// https://tools.ietf.org/html/rfc4566#section-5.14
 	// https://tools.ietf.org/html/rfc4975#section-8.1
 	for proto := range strings.SplitSeq(field, "/") {
-		if !anyOf(
+		err = l.checkToken(
 			proto,
+			ErrSDPInvalidValue,
+			l.options.Protos,
 			"UDP",
 			"RTP",
 			"AVP",
@@ -896,31 +1017,35 @@ func unmarshalMediaDescription(lex *lexer) (stateFn, error) { //nolint:cyclop
 			"IX",
 			"MRCPv2",
 			"FEC",
-		) {
-			return nil, fmt.Errorf("%w `%v`", errSDPInvalidNumericValue, field)
+		)
+		if err != nil {
+			return mediaName, err
 		}
-		newMediaDesc.MediaName.Protos = append(newMediaDesc.MediaName.Protos, proto)
+		mediaName.Protos = append(mediaName.Protos, proto)
 	}
 
 	// <fmt>...
 	for {
-		field, err = lex.readField()
+		field, err = l.readField()
 		if err != nil {
-			return nil, err
+			return mediaName, err
 		}
 		if field == "" {
 			break
 		}
-		newMediaDesc.MediaName.Formats = append(newMediaDesc.MediaName.Formats, field)
-	}
-
-	if err := lex.nextLine(); err != nil {
-		return nil, err
+		err = checkLimit("MaxFormats", l.options.Limits.MaxFormats, len(mediaName.Formats)+1)
+		if err != nil {
+			return mediaName, err
+		}
+		if l.rfc8866() {
+			if err = checkTokenSyntax(field); err != nil {
+				return mediaName, err
+			}
+		}
+		mediaName.Formats = append(mediaName.Formats, field)
 	}
 
-	lex.desc.MediaDescriptions = append(lex.desc.MediaDescriptions, &newMediaDesc)
-
-	return s12, nil
+	return mediaName, nil
 }
 
 func unmarshalMediaTitle(l *lexer) (stateFn, error) {
@@ -954,9 +1079,9 @@ func unmarshalMediaBandwidth(l *lexer) (stateFn, error) {
 	}
 
 	latestMediaDesc := l.desc.MediaDescriptions[len(l.desc.MediaDescriptions)-1]
-	bandwidth, err := unmarshalBandwidth(value)
+	bandwidth, err := l.unmarshalBandwidth(value)
 	if err != nil {
-		return nil, fmt.Errorf("%w `b=%v`", errSDPInvalidSyntax, value)
+		return nil, fmt.Errorf("%w `b=%v`", ErrSDPInvalidValue, value)
 	}
 	latestMediaDesc.Bandwidth = append(latestMediaDesc.Bandwidth, *bandwidth)
 
@@ -982,21 +1107,25 @@ func unmarshalMediaAttribute(l *lexer) (stateFn, error) {
 		return nil, err
 	}
 
-	i := strings.IndexRune(value, ':')
-	a := l.cache.getMediaAttribute()
-	if i > 0 {
-		a.Key = value[:i]
-		a.Value = value[i+1:]
-	} else {
-		a.Key = value
+	err = l.checkAttributeLimits(len(l.cache.mediaAttributes)+1, value)
+	if err != nil {
+		return nil, err
+	}
+
+	attribute := unmarshalAttribute(value)
+	if l.rfc8866() {
+		if err := checkAttribute(attribute); err != nil {
+			return nil, err
+		}
 	}
+	*l.cache.getMediaAttribute() = attribute
 
 	return s14, nil
 }
 
 func parseTimeUnits(value string) (num int64, err error) {
 	if len(value) == 0 {
-		return 0, fmt.Errorf("%w `%v`", errSDPInvalidValue, value)
+		return 0, fmt.Errorf("%w `%v`", ErrSDPInvalidValue, value)
 	}
 	k := timeShorthand(value[len(value)-1])
 	if k > 0 {
@@ -1006,7 +1135,7 @@ func parseTimeUnits(value string) (num int64, err error) {
 		num, err = strconv.ParseInt(value, 10, 64)
 	}
 	if err != nil {
-		return 0, fmt.Errorf("%w `%v`", errSDPInvalidValue, value)
+		return 0, fmt.Errorf("%w `%v`", ErrSDPInvalidValue, value)
 	}
 
 	return num * k, nil
@@ -1032,18 +1161,20 @@ func timeShorthand(b byte) int64 {
 func parsePort(value string) (int, error) {
 	port, err := strconv.Atoi(value)
 	if err != nil {
-		return 0, fmt.Errorf("%w `%v`", errSDPInvalidPortValue, value)
+		return 0, fmt.Errorf("%w `%v`", ErrSDPInvalidPortValue, value)
 	}
 
 	if port < 0 || port > 65535 {
-		return 0, fmt.Errorf("%w -- out of range `%v`", errSDPInvalidPortValue, port)
+		return 0, fmt.Errorf("%w -- out of range `%v`", ErrSDPInvalidPortValue, port)
 	}
 
 	return port, nil
 }
 
 func populateMediaAttributes(c *unmarshalCache, s *SessionDescription) {
-	if len(s.MediaDescriptions) != 0 {
+	// Attributes are only flushed once per media description, an empty cache
+	// must not reset the attributes that were already flushed.
+	if len(s.MediaDescriptions) != 0 && len(c.mediaAttributes) != 0 {
 		lastMediaDesc := s.MediaDescriptions[len(s.MediaDescriptions)-1]
 		lastMediaDesc.Attributes = c.cloneMediaAttributes()
 	}
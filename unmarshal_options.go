@@ -0,0 +1,273 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// UnmarshalOptions configures UnmarshalStringWithOptions. The zero value
+// parses as strictly as UnmarshalString.
+type UnmarshalOptions struct {
+	// Lenient enables error recovery. Instead of aborting on the first
+	// problem, the parser records a warning and continues:
+	//
+	//   - unregistered media types, protos, network types and address types
+	//     are accepted
+	//   - lines that are out of order are kept and parsed in the session or
+	//     media section they appear in
+	//   - lines that cannot be parsed, have an unknown type or repeat the
+	//     v=, o= or s= lines are skipped, while errors in the v=, o= and s=
+	//     lines of the session header remain fatal
+	//   - an m= line that cannot be parsed is skipped with its whole section
+	Lenient bool
+
+	// Preserve keeps the original text of every line. Marshal then
+	// reproduces the input byte for byte, including its line endings,
+	// whitespace and line order, and only renders the lines whose fields
+	// were modified, added or removed since parsing. Lines skipped in lenient
+	// mode are not preserved.
+	Preserve bool
+
+	// MediaTypes, Protos, NetworkTypes and AddressTypes extend the tokens
+	// registered with IANA that are accepted for the m= media type and
+	// proto and for the o= and c= network and address types. Tokens listed
+	// here are accepted without a warning.
+	MediaTypes   []string
+	Protos       []string
+	NetworkTypes []string
+	AddressTypes []string
+
+	// Limits bounds the size of the session description. A LimitError is
+	// returned as soon as a limit is exceeded.
+	Limits Limits
+
+	// Profile is the specification to parse against, SpecProfileRFC4566
+	// when unset. SpecProfileRFC8866 follows the ABNF of RFC 8866:
+	//
+	//   - the m= media type and formats may be any token, registered or not
+	//   - the b= bandwidth type may be any token
+	//   - the TTL and number of addresses of a c= multicast address are
+	//     parsed into Address.TTL and Address.Range. IP4 multicast addresses
+	//     require a TTL, IP6 ones have none, and unicast addresses have
+	//     neither
+	//   - z= has at least one pair of adjustment time and offset, and
+	//     offsets are unsigned or negative
+	//   - a= names are tokens and values contain no NUL byte
+	//
+	// The profile the description was parsed against, SpecProfileRFC4566
+	// when unset, is returned by SessionDescription.SpecProfile.
+	Profile SpecProfile
+}
+
+var errSDPLineSkipped = errors.New("sdp: line skipped")
+
+// UnmarshalStringWithOptions deserializes the session description like
+// UnmarshalString, with the behavior configured by options. In lenient mode
+// the problems that were recovered from are returned as warnings.
+func (s *SessionDescription) UnmarshalStringWithOptions(
+	value string,
+	options UnmarshalOptions,
+) ([]*ParseError, error) {
+	if err := checkLimit("MaxSize", options.Limits.MaxSize, len(value)); err != nil {
+		return nil, err
+	}
+
+	var ok bool
+	lex := &lexer{options: options}
+	if lex.cache, ok = unmarshalCachePool.Get().(*unmarshalCache); !ok {
+		return nil, errSDPCacheInvalid
+	}
+	defer unmarshalCachePool.Put(lex.cache)
+
+	lex.cache.reset()
+	lex.desc = s
+	lex.value = value
+	lex.maxLineLength = options.Limits.MaxLineLength
+
+	for state := s1; state != nil; {
+		var err error
+		lex.state = state
+		state, err = state(lex)
+		if err != nil {
+			if state, err = lex.recoverFrom(err); err != nil {
+				return lex.warnings, lex.parseError(err)
+			}
+		}
+	}
+
+	s.Attributes = lex.cache.cloneSessionAttributes()
+	populateMediaAttributes(lex.cache, lex.desc)
+
+	s.profile = options.Profile
+	if s.profile == 0 {
+		s.profile = SpecProfileRFC4566
+	}
+
+	s.preserved = nil
+	if options.Preserve {
+		s.preserved = newPreservedText(s, value, lex.skipped)
+	}
+
+	return lex.warnings, nil
+}
+
+// checkToken validates a token against the registered values and the values
+// allowed by the caller. In lenient mode unknown tokens are accepted with a
+// warning.
+func (l *lexer) checkToken(token string, cause error, allowed []string, registered ...string) error {
+	if anyOf(token, registered...) || anyOf(token, allowed...) {
+		return nil
+	}
+
+	err := fmt.Errorf("%w `%v`", cause, token)
+	if !l.options.Lenient {
+		return err
+	}
+	l.warn(err)
+
+	return nil
+}
+
+func (l *lexer) warn(err error) {
+	l.warnings = append(l.warnings, l.newParseError(err))
+}
+
+// recoverFrom records err as a warning and skips the offending line in lenient
+// mode. Parsing resumes with the state that dispatched the line, or with the
+// next m= line when the m= line itself could not be parsed.
+func (l *lexer) recoverFrom(err error) (stateFn, error) {
+	var limitErr *LimitError
+	if !l.options.Lenient || errors.Is(err, errSDPCacheInvalid) || errors.As(err, &limitErr) {
+		return nil, err
+	}
+
+	lineType := byte(0)
+	if l.lineStart < len(l.value) {
+		lineType = l.value[l.lineStart]
+	}
+
+	// The v=, o= and s= lines identify the session, so they are required to
+	// parse. Once the session header was read, repeated ones are skipped.
+	if len(l.desc.TimeDescriptions) == 0 && anyOf(string(lineType), "v", "o", "s") {
+		return nil, err
+	}
+
+	l.warn(fmt.Errorf("%w: %w", errSDPLineSkipped, err))
+
+	l.pos = l.lineStart
+	l.markSkipped()
+	if err := l.skipLine(); err != nil {
+		return nil, err
+	}
+
+	if lineType == 'm' {
+		return skipMediaSection, nil
+	}
+
+	return l.resume, nil
+}
+
+// lenientState returns the handler for a line that is not allowed at the
+// current position. Lines are parsed in the media section when one was
+// started, and in the session section otherwise. The parser then returns to
+// the state the line interrupted.
+func (l *lexer) lenientState(key byte) stateFn { //nolint:cyclop
+	var handler stateFn
+	if len(l.desc.MediaDescriptions) > 0 {
+		switch key {
+		case 'i':
+			handler = unmarshalMediaTitle
+		case 'c':
+			handler = unmarshalMediaConnectionInformation
+		case 'b':
+			handler = unmarshalMediaBandwidth
+		case 'k':
+			handler = unmarshalMediaEncryptionKey
+		case 'a':
+			handler = unmarshalMediaAttribute
+		}
+	} else {
+		switch key {
+		case 'i':
+			handler = unmarshalSessionInformation
+		case 'u':
+			handler = unmarshalURI
+		case 'e':
+			handler = unmarshalEmail
+		case 'p':
+			handler = unmarshalPhone
+		case 'c':
+			handler = unmarshalSessionConnectionInformation
+		case 'b':
+			handler = unmarshalSessionBandwidth
+		case 't':
+			handler = unmarshalTiming
+		case 'r':
+			if len(l.desc.TimeDescriptions) > 0 {
+				handler = unmarshalRepeatTimes
+			}
+		case 'z':
+			handler = unmarshalTimeZones
+		case 'k':
+			handler = unmarshalSessionEncryptionKey
+		case 'a':
+			handler = unmarshalSessionAttribute
+		case 'm':
+			// A media section always continues with media lines.
+			return unmarshalMediaDescription
+		}
+	}
+
+	if handler == nil {
+		return nil
+	}
+
+	resume := l.state
+
+	return func(l *lexer) (stateFn, error) {
+		if _, err := handler(l); err != nil {
+			return nil, err
+		}
+
+		return resume, nil
+	}
+}
+
+// skipMediaSection skips lines until the next m= line. Errors are returned,
+// so that a LimitError aborts parsing and a malformed line is recovered from
+// like any other, after which skipping resumes.
+func skipMediaSection(l *lexer) (stateFn, error) {
+	l.resume = l.state
+	for {
+		key, err := l.readType()
+		switch {
+		case errors.Is(err, io.EOF):
+			return nil, nil //nolint:nilnil
+		case err != nil:
+			return nil, err
+		case key == 'm':
+			return unmarshalMediaDescription, nil
+		}
+
+		l.markSkipped()
+		if err := l.skipLine(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// markSkipped records that the current line is dropped, so that it is not
+// preserved.
+func (l *lexer) markSkipped() {
+	if !l.options.Preserve {
+		return
+	}
+	if l.skipped == nil {
+		l.skipped = map[int]bool{}
+	}
+	l.skipped[l.lineStart] = true
+}
@@ -0,0 +1,227 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const lenientSessionHeader = "v=0\r\n" +
+	"o=- 123 1 IN IP4 127.0.0.1\r\n" +
+	"s=-\r\n" +
+	"t=0 0\r\n"
+
+func TestUnmarshalStringWithOptions_Strict(t *testing.T) {
+	for _, sdp := range []string{
+		lenientSessionHeader + "m=image 9 UDP/TCP 0\r\n",
+		lenientSessionHeader + "m=audio 9 RTP/FOO 0\r\n",
+		lenientSessionHeader + "c=ATM NSAP 47.0005\r\n",
+		lenientSessionHeader + "m=audio 9 RTP/AVP 0\r\nu=http://example.com\r\n",
+		lenientSessionHeader + "x=unknown\r\n",
+	} {
+		var sd SessionDescription
+		warnings, err := sd.UnmarshalStringWithOptions(sdp, UnmarshalOptions{})
+		assert.Error(t, err, sdp)
+		assert.Empty(t, warnings, sdp)
+		assert.Error(t, (&SessionDescription{}).UnmarshalString(sdp), sdp)
+	}
+}
+
+func TestUnmarshalStringWithOptions_Lenient(t *testing.T) { //nolint:maintidx
+	for _, test := range []struct {
+		name     string
+		sdp      string
+		expected string
+		warnings []ParseError
+		cause    error
+	}{
+		{
+			name:     "unknown media type",
+			sdp:      lenientSessionHeader + "m=image 9 UDP 0\r\n",
+			expected: lenientSessionHeader + "m=image 9 UDP 0\r\n",
+			warnings: []ParseError{{Line: 5, Column: 3, LineType: 'm', Text: "m=image 9 UDP 0"}},
+			cause:    ErrSDPInvalidValue,
+		},
+		{
+			name:     "unknown proto",
+			sdp:      lenientSessionHeader + "m=audio 9 RTP/FOO 0\r\n",
+			expected: lenientSessionHeader + "m=audio 9 RTP/FOO 0\r\n",
+			warnings: []ParseError{{Line: 5, Column: 11, LineType: 'm', Text: "m=audio 9 RTP/FOO 0"}},
+			cause:    ErrSDPInvalidValue,
+		},
+		{
+			name:     "unknown network type",
+			sdp:      lenientSessionHeader + "m=audio 9 RTP/AVP 0\r\nc=ATM NSAP 47.0005\r\n",
+			expected: lenientSessionHeader + "m=audio 9 RTP/AVP 0\r\nc=ATM NSAP 47.0005\r\n",
+			warnings: []ParseError{
+				{Line: 6, Column: 3, LineType: 'c', Text: "c=ATM NSAP 47.0005"},
+				{Line: 6, Column: 7, LineType: 'c', Text: "c=ATM NSAP 47.0005"},
+			},
+			cause: ErrSDPInvalidValue,
+		},
+		{
+			name: "out of order session line",
+			sdp: "v=0\r\n" +
+				"o=- 123 1 IN IP4 127.0.0.1\r\n" +
+				"s=-\r\n" +
+				"t=0 0\r\n" +
+				"u=http://example.com\r\n",
+			expected: "v=0\r\n" +
+				"o=- 123 1 IN IP4 127.0.0.1\r\n" +
+				"s=-\r\n" +
+				"u=http://example.com\r\n" +
+				"t=0 0\r\n",
+			warnings: []ParseError{{Line: 5, Column: 1, LineType: 'u', Text: "u=http://example.com"}},
+			cause:    ErrSDPInvalidSyntax,
+		},
+		{
+			name: "session line in media section",
+			sdp: lenientSessionHeader +
+				"m=audio 9 RTP/AVP 0\r\n" +
+				"u=http://example.com\r\n" +
+				"a=sendrecv\r\n",
+			expected: lenientSessionHeader +
+				"m=audio 9 RTP/AVP 0\r\n" +
+				"a=sendrecv\r\n",
+			warnings: []ParseError{{Line: 6, Column: 1, LineType: 'u', Text: "u=http://example.com"}},
+			cause:    errSDPLineSkipped,
+		},
+		{
+			name:     "repeated version",
+			sdp:      lenientSessionHeader + "v=0\r\na=ice-lite\r\n",
+			expected: lenientSessionHeader + "a=ice-lite\r\n",
+			warnings: []ParseError{{Line: 5, Column: 1, LineType: 'v', Text: "v=0"}},
+			cause:    errSDPLineSkipped,
+		},
+		{
+			name:     "unknown line type",
+			sdp:      lenientSessionHeader + "x=unknown\r\na=ice-lite\r\n",
+			expected: lenientSessionHeader + "a=ice-lite\r\n",
+			warnings: []ParseError{{Line: 5, Column: 1, LineType: 'x', Text: "x=unknown"}},
+			cause:    errSDPLineSkipped,
+		},
+		{
+			name: "invalid bandwidth",
+			sdp: lenientSessionHeader +
+				"m=audio 9 RTP/AVP 0\r\n" +
+				"b=AS:fast\r\n" +
+				"a=sendrecv\r\n",
+			expected: lenientSessionHeader +
+				"m=audio 9 RTP/AVP 0\r\n" +
+				"a=sendrecv\r\n",
+			warnings: []ParseError{{Line: 6, Column: 3, LineType: 'b', Text: "b=AS:fast"}},
+			cause:    ErrSDPInvalidValue,
+		},
+		{
+			name: "invalid media line",
+			sdp: lenientSessionHeader +
+				"m=audio 9 RTP/AVP 0\r\n" +
+				"a=mid:0\r\n" +
+				"m=video 99999 RTP/AVP 96\r\n" +
+				"a=mid:1\r\n" +
+				"m=audio 9 RTP/AVP 8\r\n" +
+				"a=mid:2\r\n",
+			expected: lenientSessionHeader +
+				"m=audio 9 RTP/AVP 0\r\n" +
+				"a=mid:0\r\n" +
+				"m=audio 9 RTP/AVP 8\r\n" +
+				"a=mid:2\r\n",
+			warnings: []ParseError{{Line: 7, Column: 9, LineType: 'm', Text: "m=video 99999 RTP/AVP 96"}},
+			cause:    ErrSDPInvalidPortValue,
+		},
+		{
+			name: "invalid last media line",
+			sdp: lenientSessionHeader +
+				"m=audio 9 RTP/AVP 0\r\n" +
+				"a=mid:0\r\n" +
+				"m=video 99999 RTP/AVP 96\r\n" +
+				"a=mid:1\r\n",
+			expected: lenientSessionHeader +
+				"m=audio 9 RTP/AVP 0\r\n" +
+				"a=mid:0\r\n",
+			warnings: []ParseError{{Line: 7, Column: 9, LineType: 'm', Text: "m=video 99999 RTP/AVP 96"}},
+			cause:    ErrSDPInvalidPortValue,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var sd SessionDescription
+			warnings, err := sd.UnmarshalStringWithOptions(test.sdp, UnmarshalOptions{Lenient: true})
+			assert.NoError(t, err)
+
+			actual, err := sd.Marshal()
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, string(actual))
+
+			if assert.Len(t, warnings, len(test.warnings)) {
+				for i, warning := range warnings {
+					assert.ErrorIs(t, warning, test.cause)
+					warning.Err = nil
+					assert.Equal(t, test.warnings[i], *warning)
+				}
+			}
+		})
+	}
+}
+
+func TestUnmarshalStringWithOptions_Lenient_SkippedSection(t *testing.T) {
+	skipped := lenientSessionHeader +
+		"m=audio 9 RTP/AVP 0\r\n" +
+		"m=video 99999 RTP/AVP 96\r\n" +
+		"a=tool:" + strings.Repeat("x", 100) + "\r\n" +
+		"garbage\r\n" +
+		"a=mid:1\r\n"
+
+	// The line length limit applies to the lines of a skipped section.
+	var sd SessionDescription
+	_, err := sd.UnmarshalStringWithOptions(skipped, UnmarshalOptions{
+		Lenient: true,
+		Limits:  Limits{MaxLineLength: 64},
+	})
+	var limitErr *LimitError
+	assert.ErrorAs(t, err, &limitErr)
+
+	// A malformed line is reported, and skipping goes on after it.
+	var lenient SessionDescription
+	warnings, err := lenient.UnmarshalStringWithOptions(skipped, UnmarshalOptions{Lenient: true})
+	assert.NoError(t, err)
+	if assert.Len(t, warnings, 2) {
+		assert.ErrorIs(t, warnings[0], ErrSDPInvalidPortValue)
+		assert.ErrorIs(t, warnings[1], ErrSDPInvalidSyntax)
+		assert.Equal(t, 8, warnings[1].Line)
+	}
+	if assert.Len(t, lenient.MediaDescriptions, 1) {
+		assert.Empty(t, lenient.MediaDescriptions[0].Attributes)
+	}
+}
+
+func TestUnmarshalStringWithOptions_Lenient_Fatal(t *testing.T) {
+	var sd SessionDescription
+	_, err := sd.UnmarshalStringWithOptions("v=1\r\n", UnmarshalOptions{Lenient: true})
+	assert.ErrorIs(t, err, ErrSDPInvalidValue)
+}
+
+func TestUnmarshalStringWithOptions_ExtraTokens(t *testing.T) {
+	sdp := "v=0\r\n" +
+		"o=- 123 1 ATM NSAP 47.0005\r\n" +
+		"s=-\r\n" +
+		"t=0 0\r\n" +
+		"m=image 9 udptl t38\r\n"
+
+	var sd SessionDescription
+	warnings, err := sd.UnmarshalStringWithOptions(sdp, UnmarshalOptions{
+		MediaTypes:   []string{"image"},
+		Protos:       []string{"udptl"},
+		NetworkTypes: []string{"ATM"},
+		AddressTypes: []string{"NSAP"},
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, warnings)
+
+	actual, err := sd.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, sdp, string(actual))
+}
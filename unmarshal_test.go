@@ -371,7 +371,7 @@ func TestUnmarshalRepeatTimes(t *testing.T) {
 	assert.Equal(t, RepeatTimesSDPExpected, string(actual))
 
 	err = sd.UnmarshalString(TimingSDP + "r=\r\n")
-	assert.ErrorIs(t, err, errSDPInvalidValue)
+	assert.ErrorIs(t, err, ErrSDPInvalidValue)
 }
 
 func TestUnmarshalTimeZones(t *testing.T) {
@@ -411,11 +411,11 @@ func TestUnmarshalPortRange(t *testing.T) {
 	}{
 		{
 			In:          SessionAttributesSDP + "m=video -1 RTP/AVP 99\r\n",
-			ExpectError: errSDPInvalidPortValue,
+			ExpectError: ErrSDPInvalidPortValue,
 		},
 		{
 			In:          SessionAttributesSDP + "m=video 65536 RTP/AVP 99\r\n",
-			ExpectError: errSDPInvalidPortValue,
+			ExpectError: ErrSDPInvalidPortValue,
 		},
 		{
 			In:          SessionAttributesSDP + "m=video 0 RTP/AVP 99\r\n",
@@ -427,7 +427,7 @@ func TestUnmarshalPortRange(t *testing.T) {
 		},
 		{
 			In:          SessionAttributesSDP + "m=video --- RTP/AVP 99\r\n",
-			ExpectError: errSDPInvalidPortValue,
+			ExpectError: ErrSDPInvalidPortValue,
 		},
 	} {
 		var sd SessionDescription
@@ -1324,7 +1324,7 @@ func TestUnmarshalProtocolVersion_Error_InvalidNonZeroVersion(t *testing.T) {
 
 	st, err := unmarshalProtocolVersion(l)
 	assert.Nil(t, st)
-	assert.ErrorIs(t, err, errSDPInvalidValue)
+	assert.ErrorIs(t, err, ErrSDPInvalidValue)
 }
 
 func TestUnmarshalOrigin_Error_ReadUsernameField(t *testing.T) {
@@ -1387,7 +1387,7 @@ func TestUnmarshalOrigin_Error_InvalidNetworkType(t *testing.T) {
 
 	st, err := unmarshalOrigin(l)
 	assert.Nil(t, st)
-	assert.ErrorIs(t, err, errSDPInvalidValue)
+	assert.ErrorIs(t, err, ErrSDPInvalidValue)
 }
 
 func TestUnmarshalOrigin_Error_HandleAddressType_Propagates(t *testing.T) {
@@ -1420,7 +1420,7 @@ func TestHandleAddressType_ReturnsUnderlyingError(t *testing.T) {
 		baseLexer: baseLexer{value: ""},
 	}
 
-	err := handleAddressType(l)
+	err := handleAddressType(l, &l.desc.Origin)
 	assert.Error(t, err)
 	assert.ErrorIs(t, err, io.EOF)
 }
@@ -1431,7 +1431,7 @@ func TestHandleUnicastAddress_ReturnsUnderlyingError(t *testing.T) {
 		baseLexer: baseLexer{value: ""},
 	}
 
-	err := handleUnicastAddress(l)
+	err := handleUnicastAddress(l, &l.desc.Origin)
 	assert.Error(t, err)
 	assert.ErrorIs(t, err, io.EOF)
 }
@@ -1497,7 +1497,7 @@ func TestUnmarshalConnectionInformation_ErrInvalidNetworkType(t *testing.T) {
 
 	ci, err := l.unmarshalConnectionInformation()
 	assert.Nil(t, ci)
-	assert.ErrorIs(t, err, errSDPInvalidValue)
+	assert.ErrorIs(t, err, ErrSDPInvalidValue)
 }
 
 func TestUnmarshalConnectionInformation_ErrReadAddressType(t *testing.T) {
@@ -1514,7 +1514,7 @@ func TestUnmarshalConnectionInformation_ErrInvalidAddressType(t *testing.T) {
 
 	ci, err := l.unmarshalConnectionInformation()
 	assert.Nil(t, ci)
-	assert.ErrorIs(t, err, errSDPInvalidValue)
+	assert.ErrorIs(t, err, ErrSDPInvalidValue)
 }
 
 func TestUnmarshalConnectionInformation_ErrReadAddress(t *testing.T) {
@@ -1545,19 +1545,19 @@ func TestUnmarshalSessionBandwidth_Error_InvalidBandwidthValue(t *testing.T) {
 
 	st, err := unmarshalSessionBandwidth(l)
 	assert.Nil(t, st)
-	assert.ErrorIs(t, err, errSDPInvalidValue)
+	assert.ErrorIs(t, err, ErrSDPInvalidValue)
 }
 
 func TestUnmarshalBandwidth_InvalidType(t *testing.T) {
 	bw, err := unmarshalBandwidth("ZZ:123")
 	assert.Nil(t, bw)
-	assert.ErrorIs(t, err, errSDPInvalidValue)
+	assert.ErrorIs(t, err, ErrSDPInvalidValue)
 }
 
 func TestUnmarshalBandwidth_InvalidNumeric(t *testing.T) {
 	bw, err := unmarshalBandwidth("AS:notanumber")
 	assert.Nil(t, bw)
-	assert.ErrorIs(t, err, errSDPInvalidNumericValue)
+	assert.ErrorIs(t, err, ErrSDPInvalidNumericValue)
 }
 
 func TestUnmarshalTiming_Error_StartTime(t *testing.T) {
@@ -1627,7 +1627,7 @@ func TestUnmarshalRepeatTimes_Error_DurationParse(t *testing.T) {
 
 	st, err := unmarshalRepeatTimes(l)
 	assert.Nil(t, st)
-	assert.ErrorIs(t, err, errSDPInvalidValue)
+	assert.ErrorIs(t, err, ErrSDPInvalidValue)
 }
 
 func TestUnmarshalRepeatTimes_Error_OffsetParse(t *testing.T) {
@@ -1641,7 +1641,7 @@ func TestUnmarshalRepeatTimes_Error_OffsetParse(t *testing.T) {
 
 	st, err := unmarshalRepeatTimes(l)
 	assert.Nil(t, st)
-	assert.ErrorIs(t, err, errSDPInvalidValue)
+	assert.ErrorIs(t, err, ErrSDPInvalidValue)
 }
 
 func TestUnmarshalRepeatTimes_Error_ReadFieldInsideLoop(t *testing.T) {
@@ -1692,7 +1692,7 @@ func TestUnmarshalTimeZones_Error_ParseOffset(t *testing.T) {
 
 	st, err := unmarshalTimeZones(l)
 	assert.Nil(t, st)
-	assert.ErrorIs(t, err, errSDPInvalidValue)
+	assert.ErrorIs(t, err, ErrSDPInvalidValue)
 }
 
 func TestUnmarshalSessionEncryptionKey_Error_ReadLine(t *testing.T) {
@@ -1739,7 +1739,7 @@ func TestUnmarshalMediaDescription_Error_InvalidMediaToken(t *testing.T) {
 
 	st, err := unmarshalMediaDescription(l)
 	assert.Nil(t, st)
-	assert.ErrorIs(t, err, errSDPInvalidValue)
+	assert.ErrorIs(t, err, ErrSDPInvalidValue)
 }
 
 func TestUnmarshalMediaDescription_Error_ReadPortField(t *testing.T) {
@@ -1763,7 +1763,7 @@ func TestUnmarshalMediaDescription_Error_PortRangeInvalid(t *testing.T) {
 
 	st, err := unmarshalMediaDescription(l)
 	assert.Nil(t, st)
-	assert.ErrorIs(t, err, errSDPInvalidValue)
+	assert.ErrorIs(t, err, ErrSDPInvalidValue)
 }
 
 func TestUnmarshalMediaDescription_Error_ReadProtoField(t *testing.T) {
@@ -1821,7 +1821,7 @@ func TestUnmarshalMediaDescription_Error_InvalidProto(t *testing.T) {
 
 	st, err := unmarshalMediaDescription(l)
 	assert.Nil(t, st)
-	assert.ErrorIs(t, err, errSDPInvalidNumericValue)
+	assert.ErrorIs(t, err, ErrSDPInvalidValue)
 }
 
 func TestUnmarshalMediaTitle_Error_ReadLine(t *testing.T) {
@@ -1873,7 +1873,7 @@ func TestUnmarshalMediaBandwidth_Error_InvalidBandwidth(t *testing.T) {
 
 	st, err := unmarshalMediaBandwidth(l)
 	assert.Nil(t, st)
-	assert.ErrorIs(t, err, errSDPInvalidSyntax)
+	assert.ErrorIs(t, err, ErrSDPInvalidValue)
 }
 
 func TestUnmarshalMediaEncryptionKey_Error_ReadLine(t *testing.T) {
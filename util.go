@@ -27,6 +27,7 @@ var (
 	errCodecNotFound       = errors.New("codec not found")
 	errSyntaxError         = errors.New("SyntaxError")
 	errFieldMissing        = errors.New("field missing")
+	errConnectionRole      = errors.New("invalid connection role string")
 )
 
 // ConnectionRole indicates which of the end points should initiate the connection establishment.
@@ -62,6 +63,24 @@ func (t ConnectionRole) String() string {
 	}
 }
 
+// NewConnectionRole defines a procedure for creating a new connection role
+// from the value of an "a=setup" attribute.
+// https://datatracker.ietf.org/doc/html/rfc4145#section-4
+func NewConnectionRole(raw string) (ConnectionRole, error) {
+	switch raw {
+	case "active":
+		return ConnectionRoleActive, nil
+	case "passive":
+		return ConnectionRolePassive, nil
+	case "actpass":
+		return ConnectionRoleActpass, nil
+	case "holdconn":
+		return ConnectionRoleHoldconn, nil
+	default:
+		return ConnectionRole(unknown), fmt.Errorf("%w `%v`", errConnectionRole, raw)
+	}
+}
+
 func newSessionID() (uint64, error) {
 	// https://tools.ietf.org/html/draft-ietf-rtcweb-jsep-26#section-5.2.1
 	// Session ID is recommended to be constructed by generating a 64-bit
@@ -226,12 +245,172 @@ func mergeCodecs(codec Codec, codecs map[uint8]Codec) {
 	codecs[savedCodec.PayloadType] = savedCodec
 }
 
-// GetCodecMap parses the SessionDescription and returns a map of payload
-// type to Codec. This allows callers to build the map once and look up
-// multiple payload types without rebuilding it each time.
-func (s *SessionDescription) GetCodecMap() map[uint8]Codec { //nolint:cyclop
-	codecs := map[uint8]Codec{
-		// static codecs that do not require a rtpmap
+// staticCodec returns the codec statically assigned to the payload type for
+// the given media type, see the tables in RFC 3551.
+// https://tools.ietf.org/html/rfc3551#section-6
+func staticCodec(media string, payloadType uint8) (Codec, bool) { //nolint:cyclop
+	var codec Codec
+	switch media {
+	case "audio":
+		switch payloadType {
+		case 0:
+			codec = Codec{Name: "PCMU", ClockRate: 8000}
+		case 3:
+			codec = Codec{Name: "GSM", ClockRate: 8000}
+		case 4:
+			codec = Codec{Name: "G723", ClockRate: 8000}
+		case 5:
+			codec = Codec{Name: "DVI4", ClockRate: 8000}
+		case 6:
+			codec = Codec{Name: "DVI4", ClockRate: 16000}
+		case 7:
+			codec = Codec{Name: "LPC", ClockRate: 8000}
+		case 8:
+			codec = Codec{Name: "PCMA", ClockRate: 8000}
+		case 9:
+			codec = Codec{Name: "G722", ClockRate: 8000}
+		case 10:
+			codec = Codec{Name: "L16", ClockRate: 44100, EncodingParameters: "2"}
+		case 11:
+			codec = Codec{Name: "L16", ClockRate: 44100}
+		case 12:
+			codec = Codec{Name: "QCELP", ClockRate: 8000}
+		case 13:
+			codec = Codec{Name: "CN", ClockRate: 8000}
+		case 14:
+			codec = Codec{Name: "MPA", ClockRate: 90000}
+		case 15:
+			codec = Codec{Name: "G728", ClockRate: 8000}
+		case 16:
+			codec = Codec{Name: "DVI4", ClockRate: 11025}
+		case 17:
+			codec = Codec{Name: "DVI4", ClockRate: 22050}
+		case 18:
+			codec = Codec{Name: "G729", ClockRate: 8000}
+		default:
+			return codec, false
+		}
+	case "video":
+		switch payloadType {
+		case 25:
+			codec = Codec{Name: "CelB", ClockRate: 90000}
+		case 26:
+			codec = Codec{Name: "JPEG", ClockRate: 90000}
+		case 28:
+			codec = Codec{Name: "nv", ClockRate: 90000}
+		case 31:
+			codec = Codec{Name: "H261", ClockRate: 90000}
+		case 32:
+			codec = Codec{Name: "MPV", ClockRate: 90000}
+		case 33:
+			codec = Codec{Name: "MP2T", ClockRate: 90000}
+		case 34:
+			codec = Codec{Name: "H263", ClockRate: 90000}
+		default:
+			return codec, false
+		}
+	default:
+		return codec, false
+	}
+
+	codec.PayloadType = payloadType
+
+	return codec, true
+}
+
+// codecMap parses the rtpmap, fmtp and rtcp-fb attributes of this media
+// description only. Static payload types listed in the m= line are resolved
+// from the RFC 3551 tables for the media type, and wildcard rtcp-fb values
+// apply to the codecs of this media description only.
+func (d *MediaDescription) codecMap() map[uint8]Codec {
+	codecs := map[uint8]Codec{}
+
+	wildcardRTCPFeedback := []string{}
+	for _, a := range d.Attributes {
+		attr := a.String()
+		switch {
+		case strings.HasPrefix(attr, "rtpmap:"):
+			codec, err := parseRtpmap(attr)
+			if err == nil {
+				mergeCodecs(codec, codecs)
+			}
+		case strings.HasPrefix(attr, "fmtp:"):
+			codec, err := parseFmtp(attr)
+			if err == nil {
+				mergeCodecs(codec, codecs)
+			}
+		case strings.HasPrefix(attr, "rtcp-fb:"):
+			codec, isWildcard, err := parseRtcpFb(attr)
+			switch {
+			case err != nil:
+			case isWildcard:
+				wildcardRTCPFeedback = append(wildcardRTCPFeedback, codec.RTCPFeedback...)
+			default:
+				mergeCodecs(codec, codecs)
+			}
+		}
+	}
+
+	// An explicit rtpmap takes precedence over the static assignment.
+	for _, format := range d.MediaName.Formats {
+		payloadType, err := strconv.ParseUint(format, 10, 8)
+		if err != nil {
+			continue
+		}
+		if codecs[uint8(payloadType)].Name != "" {
+			continue
+		}
+		if codec, ok := staticCodec(d.MediaName.Media, uint8(payloadType)); ok {
+			mergeCodecs(codec, codecs)
+		}
+	}
+
+	for i, codec := range codecs {
+		for _, newRTCPFeedback := range wildcardRTCPFeedback {
+			codec.appendRTCPFeedback(newRTCPFeedback)
+		}
+
+		codecs[i] = codec
+	}
+
+	return codecs
+}
+
+// Codecs parses the media description and returns its codecs. Parsing is
+// scoped to this media description, so payload types reused by other media
+// descriptions of the session do not interfere. Codecs are returned in the
+// order of the m= line formats, followed by codecs that are only described by
+// attributes in ascending payload type order.
+func (d *MediaDescription) Codecs() []Codec {
+	codecs := d.codecMap()
+	result := make([]Codec, 0, len(codecs))
+
+	for _, format := range d.MediaName.Formats {
+		payloadType, err := strconv.ParseUint(format, 10, 8)
+		if err != nil {
+			continue
+		}
+		if codec, ok := codecs[uint8(payloadType)]; ok {
+			result = append(result, codec)
+			delete(codecs, uint8(payloadType))
+		}
+	}
+
+	remaining := make([]Codec, 0, len(codecs))
+	for _, codec := range codecs {
+		remaining = append(remaining, codec)
+	}
+	sort.Slice(remaining, func(i, j int) bool {
+		return remaining[i].PayloadType < remaining[j].PayloadType
+	})
+
+	return append(result, remaining...)
+}
+
+// legacyStaticCodecs are the static codecs that GetCodecMap has always
+// reported, even when no media description lists them.
+func legacyStaticCodecs() map[uint8]Codec {
+	return map[uint8]Codec{
 		0: {
 			PayloadType: 0,
 			Name:        "PCMU",
@@ -248,41 +427,28 @@ func (s *SessionDescription) GetCodecMap() map[uint8]Codec { //nolint:cyclop
 			ClockRate:   8000,
 		},
 	}
+}
+
+// GetCodecMap parses the SessionDescription and returns a map of payload
+// type to Codec. This allows callers to build the map once and look up
+// multiple payload types without rebuilding it each time.
+//
+// The map is built from the Codecs of every media description. When several
+// media descriptions use the same payload type, the first one wins. Use
+// MediaDescription.Codecs when payload types are reused across sections.
+func (s *SessionDescription) GetCodecMap() map[uint8]Codec {
+	codecs := legacyStaticCodecs()
+	defined := map[uint8]bool{}
 
-	wildcardRTCPFeedback := []string{}
 	for _, m := range s.MediaDescriptions {
-		for _, a := range m.Attributes {
-			attr := a.String()
-			switch {
-			case strings.HasPrefix(attr, "rtpmap:"):
-				codec, err := parseRtpmap(attr)
-				if err == nil {
-					mergeCodecs(codec, codecs)
-				}
-			case strings.HasPrefix(attr, "fmtp:"):
-				codec, err := parseFmtp(attr)
-				if err == nil {
-					mergeCodecs(codec, codecs)
-				}
-			case strings.HasPrefix(attr, "rtcp-fb:"):
-				codec, isWildcard, err := parseRtcpFb(attr)
-				switch {
-				case err != nil:
-				case isWildcard:
-					wildcardRTCPFeedback = append(wildcardRTCPFeedback, codec.RTCPFeedback...)
-				default:
-					mergeCodecs(codec, codecs)
-				}
+		for _, codec := range m.Codecs() {
+			if defined[codec.PayloadType] {
+				continue
 			}
-		}
-	}
 
-	for i, codec := range codecs {
-		for _, newRTCPFeedback := range wildcardRTCPFeedback {
-			codec.appendRTCPFeedback(newRTCPFeedback)
+			defined[codec.PayloadType] = true
+			codecs[codec.PayloadType] = codec
 		}
-
-		codecs[i] = codec
 	}
 
 	return codecs
@@ -320,7 +486,7 @@ func codecsMatch(wanted, got Codec) bool {
 	if wanted.EncodingParameters != "" && wanted.EncodingParameters != got.EncodingParameters {
 		return false
 	}
-	if wanted.Fmtp != "" && !equivalentFmtp(wanted.Fmtp, got.Fmtp) {
+	if wanted.Fmtp != "" && !fmtpMatch(got.Name, wanted.Fmtp, got.Fmtp) {
 		return false
 	}
 
@@ -354,11 +520,20 @@ func (s *SessionDescription) GetCodecsForPayloadTypes(payloadTypes []uint8) ([]C
 }
 
 // GetPayloadTypeForCodec scans the SessionDescription for a codec that matches the provided codec
-// as closely as possible and returns its payload type.
+// as closely as possible and returns its payload type. Media descriptions are
+// searched in order, and codecs in the order of their m= line formats. The
+// fmtp of the codec, when set, is compared with the rules of its payload
+// format, see ParseFmtp.
 func (s *SessionDescription) GetPayloadTypeForCodec(wanted Codec) (uint8, error) {
-	codecs := s.GetCodecMap()
+	for _, m := range s.MediaDescriptions {
+		for _, codec := range m.Codecs() {
+			if codecsMatch(wanted, codec) {
+				return codec.PayloadType, nil
+			}
+		}
+	}
 
-	for payloadType, codec := range codecs {
+	for payloadType, codec := range legacyStaticCodecs() {
 		if codecsMatch(wanted, codec) {
 			return payloadType, nil
 		}
@@ -370,14 +545,27 @@ func (s *SessionDescription) GetPayloadTypeForCodec(wanted Codec) (uint8, error)
 type stateFn func(*lexer) (stateFn, error)
 
 type lexer struct {
-	desc  *SessionDescription
-	cache *unmarshalCache
+	desc     *SessionDescription
+	cache    *unmarshalCache
+	options  UnmarshalOptions
+	warnings []*ParseError
+	skipped  map[int]bool
+
+	// candidates counts the candidate attributes for Limits.MaxCandidates.
+	candidates int
+
+	// state is the state being run and resume the last state that read a
+	// line type, which is where lenient parsing resumes after an error.
+	state  stateFn
+	resume stateFn
+
 	baseLexer
 }
 
 type keyToState func(key byte) stateFn
 
 func (l *lexer) handleType(fn keyToState) (stateFn, error) {
+	l.resume = l.state
 	key, err := l.readType()
 	if errors.Is(err, io.EOF) && key == 0 {
 		return nil, nil //nolint:nilnil
@@ -389,5 +577,15 @@ func (l *lexer) handleType(fn keyToState) (stateFn, error) {
 		return res, nil
 	}
 
-	return nil, l.syntaxError()
+	// The line type is not allowed at this position.
+	err = syntaxError{s: l.value, i: l.lineStart}
+	if l.options.Lenient {
+		if res := l.lenientState(key); res != nil {
+			l.warn(err)
+
+			return res, nil
+		}
+	}
+
+	return nil, err
 }
@@ -521,3 +521,87 @@ func TestLexer_HandleType_SyntaxErrorWhenFnReturnsNil(t *testing.T) {
 	var se syntaxError
 	assert.ErrorAs(t, err, &se)
 }
+
+func getReusedPayloadTypeSessionDescription() SessionDescription {
+	return SessionDescription{
+		MediaDescriptions: []*MediaDescription{
+			{
+				MediaName: MediaName{
+					Media:   "audio",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"96", "0", "18"},
+				},
+				Attributes: []Attribute{
+					NewAttribute("rtpmap", "96 telephone-event/8000"),
+					NewAttribute("fmtp", "96 0-15"),
+					NewAttribute("rtcp-fb", "* nack"),
+				},
+			},
+			{
+				MediaName: MediaName{
+					Media:   "video",
+					Protos:  []string{"RTP", "AVPF"},
+					Formats: []string{"96", "34"},
+				},
+				Attributes: []Attribute{
+					NewAttribute("rtpmap", "96 VP8/90000"),
+					NewAttribute("rtcp-fb", "96 goog-remb"),
+				},
+			},
+		},
+	}
+}
+
+func TestMediaDescription_Codecs(t *testing.T) {
+	sd := getReusedPayloadTypeSessionDescription()
+
+	assert.Equal(t, []Codec{
+		{
+			PayloadType:  96,
+			Name:         "telephone-event",
+			ClockRate:    8000,
+			Fmtp:         "0-15",
+			RTCPFeedback: []string{"nack"},
+		},
+		{PayloadType: 0, Name: "PCMU", ClockRate: 8000, RTCPFeedback: []string{"nack"}},
+		{PayloadType: 18, Name: "G729", ClockRate: 8000, RTCPFeedback: []string{"nack"}},
+	}, sd.MediaDescriptions[0].Codecs())
+
+	assert.Equal(t, []Codec{
+		{PayloadType: 96, Name: "VP8", ClockRate: 90000, RTCPFeedback: []string{"goog-remb"}},
+		{PayloadType: 34, Name: "H263", ClockRate: 90000},
+	}, sd.MediaDescriptions[1].Codecs())
+}
+
+func TestMediaDescription_Codecs_RtpmapOverridesStatic(t *testing.T) {
+	md := &MediaDescription{
+		MediaName: MediaName{
+			Media:   "audio",
+			Formats: []string{"10", "97"},
+		},
+		Attributes: []Attribute{
+			NewAttribute("rtpmap", "10 L16/8000"),
+			NewAttribute("rtpmap", "98 opus/48000/2"),
+		},
+	}
+
+	assert.Equal(t, []Codec{
+		{PayloadType: 10, Name: "L16", ClockRate: 8000},
+		{PayloadType: 98, Name: "opus", ClockRate: 48000, EncodingParameters: "2"},
+	}, md.Codecs())
+}
+
+func TestGetCodecMap_ReusedPayloadType(t *testing.T) {
+	sd := getReusedPayloadTypeSessionDescription()
+
+	codecs := sd.GetCodecMap()
+	assert.Equal(t, "telephone-event", codecs[96].Name)
+	assert.Equal(t, "0-15", codecs[96].Fmtp)
+	assert.Equal(t, []string{"nack"}, codecs[96].RTCPFeedback)
+	assert.Equal(t, "H263", codecs[34].Name)
+	assert.Empty(t, codecs[34].RTCPFeedback)
+
+	payloadType, err := sd.GetPayloadTypeForCodec(Codec{Name: "VP8"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(96), payloadType)
+}
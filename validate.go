@@ -0,0 +1,329 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	errJSEPMissingICECredentials = errors.New("sdp: missing ice-ufrag or ice-pwd")
+	errJSEPMissingFingerprint    = errors.New("sdp: missing fingerprint")
+	errJSEPMissingSetup          = errors.New("sdp: missing setup")
+	errJSEPDuplicateMID          = errors.New("sdp: mid is used by more than one m-section")
+	errJSEPBundleOnlyNotBundled  = errors.New("sdp: bundle-only m-section is not part of a BUNDLE group")
+	errJSEPMissingRTCPMux        = errors.New("sdp: missing rtcp-mux")
+	errJSEPInvalidMsidSemantic   = errors.New("sdp: invalid msid-semantic")
+	errJSEPUnknownMsidSemantic   = errors.New("sdp: unknown msid-semantic")
+	errJSEPUnmappedPayloadType   = errors.New("sdp: payload type has no rtpmap")
+	errJSEPUnlistedPayloadType   = errors.New("sdp: payload type is not part of the m= line")
+)
+
+// ValidationSeverity is the severity of a ValidationIssue.
+type ValidationSeverity int
+
+const (
+	// ValidationError is a violation of a requirement that the remote
+	// endpoint may reject the description for.
+	ValidationError ValidationSeverity = iota + 1
+	// ValidationWarning is a deviation that is tolerated by most endpoints.
+	ValidationWarning
+)
+
+func (v ValidationSeverity) String() string {
+	switch v {
+	case ValidationError:
+		return "error"
+	case ValidationWarning:
+		return "warning"
+	default:
+		return ""
+	}
+}
+
+// ValidationIssue is an issue found by ValidateJSEP.
+type ValidationIssue struct {
+	Severity ValidationSeverity
+	// MediaIndex is the index of the m-section the issue is located in, -1
+	// for the session level.
+	MediaIndex int
+	// Key is the key of the attribute the issue is about, empty when the
+	// issue is about the m-section itself.
+	Key string
+	// Err describes the issue. It can be matched with errors.Is against the
+	// errors of the sdp package, such as those of ValidateExtMaps.
+	Err error
+}
+
+// String renders the issue on one line, such as
+// "error: m-section 1: a=rtcp-mux: sdp: missing rtcp-mux".
+func (i ValidationIssue) String() string {
+	location := "session"
+	if i.MediaIndex >= 0 {
+		location = "m-section " + strconv.Itoa(i.MediaIndex)
+	}
+	if i.Key != "" {
+		location += ": a=" + i.Key
+	}
+
+	return i.Severity.String() + ": " + location + ": " + i.Err.Error()
+}
+
+// ValidateJSEP checks that the description follows the rules of JSEP for
+// offers and answers, and returns the issues found in the order of the
+// description. It checks that:
+//
+//   - every m-section has a unique mid
+//   - BUNDLE groups refer to existing mids, do not overlap, have a usable
+//     tagged m-section, and bundle-only m-sections are part of one
+//   - the m-sections that carry a transport, which are those outside of a
+//     BUNDLE group and the tagged m-sections, have ICE credentials, and a
+//     fingerprint and setup role when they use DTLS, at media or session level
+//   - RTP m-sections have rtcp-mux, an rtpmap for each payload type that is
+//     not statically assigned to their media type, and no rtpmap, fmtp or rtcp-fb for payload types outside the m= line
+//   - msid-semantic has the WMS semantic
+//   - extmap IDs are valid and consistent, as checked by ValidateExtMaps
+//
+// Rejected m-sections are only checked for their mid.
+//
+// https://datatracker.ietf.org/doc/html/rfc9429#section-5.2.1
+// https://datatracker.ietf.org/doc/html/rfc9429#section-5.8
+func (s *SessionDescription) ValidateJSEP() []ValidationIssue {
+	var issues []ValidationIssue
+	issues = s.validateMsidSemantic(issues)
+	issues = s.validateMIDs(issues)
+
+	transports, issues := s.validateBundle(issues)
+	for i, md := range s.MediaDescriptions {
+		if md.IsRejected() {
+			continue
+		}
+
+		if transports[i] {
+			issues = s.validateTransport(issues, i, md)
+		}
+		if isRTPProtocol(md.MediaName.Protos) {
+			issues = validateRTP(issues, i, md)
+		}
+		if err := validateExtMaps(md, md.AllowsMixedExtMaps(s)); err != nil {
+			issues = appendIssue(issues, ValidationError, i, AttrKeyExtMap, err)
+		}
+	}
+
+	return issues
+}
+
+func appendIssue(
+	issues []ValidationIssue, severity ValidationSeverity, index int, key string, err error,
+) []ValidationIssue {
+	return append(issues, ValidationIssue{Severity: severity, MediaIndex: index, Key: key, Err: err})
+}
+
+// validateMsidSemantic checks that "a=msid-semantic" has a semantic token,
+// which is WMS for WebRTC, such as in "a=msid-semantic: WMS *".
+// https://datatracker.ietf.org/doc/html/draft-ietf-mmusic-msid-16#section-4
+func (s *SessionDescription) validateMsidSemantic(issues []ValidationIssue) []ValidationIssue {
+	for _, a := range s.Attributes {
+		if a.Key != AttrKeyMsidSemantic {
+			continue
+		}
+
+		fields := strings.Fields(a.Value)
+		switch {
+		case len(fields) == 0:
+			issues = appendIssue(issues, ValidationError, -1, a.Key, errJSEPInvalidMsidSemantic)
+		case fields[0] != SemanticTokenWebRTCMediaStreams:
+			issues = appendIssue(issues, ValidationWarning, -1, a.Key,
+				fmt.Errorf("%w: %v", errJSEPUnknownMsidSemantic, fields[0]))
+		}
+	}
+
+	return issues
+}
+
+// validateMIDs checks that every m-section that is not rejected has a mid,
+// and that no mid is used twice.
+// https://datatracker.ietf.org/doc/html/rfc9429#section-5.2.1
+func (s *SessionDescription) validateMIDs(issues []ValidationIssue) []ValidationIssue {
+	mids := map[string]bool{}
+	for i, md := range s.MediaDescriptions {
+		mid, ok := md.Attribute(AttrKeyMID)
+		switch {
+		case !ok || mid == "":
+			if !md.IsRejected() {
+				issues = appendIssue(issues, ValidationError, i, AttrKeyMID, errMissingMID)
+			}
+		case mids[mid]:
+			issues = appendIssue(issues, ValidationError, i, AttrKeyMID, fmt.Errorf("%w: %v", errJSEPDuplicateMID, mid))
+		default:
+			mids[mid] = true
+		}
+	}
+
+	return issues
+}
+
+// validateBundle checks the BUNDLE groups and returns, per m-section, whether
+// it carries its own transport.
+// https://datatracker.ietf.org/doc/html/rfc8843#section-7
+func (s *SessionDescription) validateBundle(issues []ValidationIssue) ([]bool, []ValidationIssue) {
+	transports := make([]bool, len(s.MediaDescriptions))
+	for i := range transports {
+		transports[i] = true
+	}
+
+	groups, err := s.GroupsBySemantics(SemanticTokenBundle)
+	if err != nil {
+		return transports, appendIssue(issues, ValidationError, -1, AttrKeyGroup, err)
+	}
+
+	bundled := map[string]bool{}
+	for _, group := range groups {
+		for _, mid := range group.MIDs {
+			switch {
+			case bundled[mid]:
+				issues = appendIssue(issues, ValidationError, -1, AttrKeyGroup, fmt.Errorf("%w: %v", errGroupDuplicateMID, mid))
+			case !s.hasMID(mid):
+				issues = appendIssue(issues, ValidationError, -1, AttrKeyGroup,
+					fmt.Errorf("%w: %v %v", errGroupUnknownMID, group.Semantics, mid))
+			}
+			bundled[mid] = true
+		}
+
+		if _, err := s.BundleTaggedMediaDescription(group); err != nil {
+			issues = appendIssue(issues, ValidationError, -1, AttrKeyGroup, err)
+		}
+		if err := validateBundleExtMaps(s, group); err != nil {
+			issues = appendIssue(issues, ValidationError, -1, AttrKeyGroup, err)
+		}
+	}
+
+	for i, md := range s.MediaDescriptions {
+		mid, _ := md.Attribute(AttrKeyMID)
+		switch {
+		case mid != "" && bundled[mid]:
+			transports[i] = isBundleTag(groups, mid)
+		case md.IsBundleOnly():
+			transports[i] = false
+			issues = appendIssue(issues, ValidationError, i, AttrKeyBundleOnly, errJSEPBundleOnlyNotBundled)
+		}
+	}
+
+	return transports, issues
+}
+
+func (s *SessionDescription) hasMID(mid string) bool {
+	_, ok := s.MediaDescriptionByMID(mid)
+
+	return ok
+}
+
+func isBundleTag(groups []Group, mid string) bool {
+	for _, group := range groups {
+		if len(group.MIDs) > 0 && group.MIDs[0] == mid {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateTransport checks the ICE and DTLS attributes of an m-section that
+// carries its own transport.
+// https://datatracker.ietf.org/doc/html/rfc8839#section-5.4
+// https://datatracker.ietf.org/doc/html/rfc8842#section-5
+func (s *SessionDescription) validateTransport(
+	issues []ValidationIssue, index int, md *MediaDescription,
+) []ValidationIssue {
+	if ufrag, pwd := iceCredentials(s, md); ufrag == "" || pwd == "" {
+		issues = appendIssue(issues, ValidationError, index, attrKeyICEUfrag, errJSEPMissingICECredentials)
+	}
+
+	if !usesDTLS(md.MediaName.Protos) {
+		return issues
+	}
+
+	fingerprints, err := md.Fingerprints()
+	if err == nil && len(fingerprints) == 0 {
+		fingerprints, err = s.Fingerprints()
+	}
+	switch {
+	case err != nil:
+		issues = appendIssue(issues, ValidationError, index, AttrKeyFingerprint, err)
+	case len(fingerprints) == 0:
+		issues = appendIssue(issues, ValidationError, index, AttrKeyFingerprint, errJSEPMissingFingerprint)
+	}
+
+	_, mediaSetup := md.Attribute(AttrKeyConnectionSetup)
+	_, sessionSetup := s.Attribute(AttrKeyConnectionSetup)
+	if !mediaSetup && !sessionSetup {
+		return appendIssue(issues, ValidationError, index, AttrKeyConnectionSetup, errJSEPMissingSetup)
+	}
+	if _, err := md.EffectiveConnectionRole(s); err != nil {
+		issues = appendIssue(issues, ValidationError, index, AttrKeyConnectionSetup, err)
+	}
+
+	return issues
+}
+
+func usesDTLS(protos []string) bool {
+	return slices.Contains(protos, "TLS") || slices.Contains(protos, "DTLS")
+}
+
+// validateRTP checks rtcp-mux and the payload types of an RTP m-section.
+// Only the payload types statically assigned to the media type in RFC 3551
+// may go without an rtpmap.
+// https://datatracker.ietf.org/doc/html/rfc9429#section-5.2.1
+// https://datatracker.ietf.org/doc/html/rfc8866#section-6.6
+func validateRTP(issues []ValidationIssue, index int, md *MediaDescription) []ValidationIssue {
+	if _, ok := md.Attribute(AttrKeyRTCPMux); !ok {
+		issues = appendIssue(issues, ValidationError, index, AttrKeyRTCPMux, errJSEPMissingRTCPMux)
+	}
+
+	listed := map[uint8]bool{}
+	for _, format := range md.MediaName.Formats {
+		payloadType, err := parsePayloadType(format)
+		if err != nil {
+			issues = appendIssue(issues, ValidationError, index, "", err)
+
+			continue
+		}
+		listed[payloadType] = true
+	}
+
+	mapped := map[uint8]bool{}
+	for _, a := range md.Attributes {
+		if a.Key != attrKeyRtpmap && a.Key != attrKeyFmtp && a.Key != attrKeyRtcpFb {
+			continue
+		}
+
+		value, _, _ := strings.Cut(a.Value, " ")
+		if a.Key == attrKeyRtcpFb && value == "*" {
+			continue
+		}
+		payloadType, err := parsePayloadType(value)
+		switch {
+		case err != nil:
+			issues = appendIssue(issues, ValidationError, index, a.Key, err)
+		case !listed[payloadType]:
+			issues = appendIssue(issues, ValidationWarning, index, a.Key,
+				fmt.Errorf("%w: %d", errJSEPUnlistedPayloadType, payloadType))
+		case a.Key == attrKeyRtpmap:
+			mapped[payloadType] = true
+		}
+	}
+
+	for _, payloadType := range md.PayloadTypes() {
+		if _, static := staticCodec(md.MediaName.Media, payloadType); !static && !mapped[payloadType] {
+			issues = appendIssue(issues, ValidationError, index, attrKeyRtpmap,
+				fmt.Errorf("%w: %d", errJSEPUnmappedPayloadType, payloadType))
+		}
+	}
+
+	return issues
+}
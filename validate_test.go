@@ -0,0 +1,262 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package sdp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const jsepValidSDP = "v=0\r\n" +
+	"o=- 4215775240449105457 2 IN IP4 127.0.0.1\r\n" +
+	"s=-\r\n" +
+	"t=0 0\r\n" +
+	"a=group:BUNDLE 0 1 2\r\n" +
+	"a=msid-semantic: WMS *\r\n" +
+	"a=fingerprint:sha-256 " +
+	"6B:8B:F0:65:5F:78:E2:51:3B:AC:6F:F3:3F:46:1B:35:DC:B8:5F:64:1A:24:C2:43:F0:A1:58:D0:A1:2C:19:08\r\n" +
+	"m=audio 9 UDP/TLS/RTP/SAVPF 111 0\r\n" +
+	"c=IN IP4 0.0.0.0\r\n" +
+	"a=mid:0\r\n" +
+	"a=ice-ufrag:abcd\r\n" +
+	"a=ice-pwd:0123456789012345678901\r\n" +
+	"a=setup:actpass\r\n" +
+	"a=extmap:3 urn:ietf:params:rtp-hdrext:sdes:mid\r\n" +
+	"a=rtcp-mux\r\n" +
+	"a=rtpmap:111 opus/48000/2\r\n" +
+	"a=fmtp:111 minptime=10;useinbandfec=1\r\n" +
+	"m=video 0 UDP/TLS/RTP/SAVPF 96\r\n" +
+	"c=IN IP4 0.0.0.0\r\n" +
+	"a=mid:1\r\n" +
+	"a=bundle-only\r\n" +
+	"a=extmap:3 urn:ietf:params:rtp-hdrext:sdes:mid\r\n" +
+	"a=rtcp-mux\r\n" +
+	"a=rtpmap:96 VP8/90000\r\n" +
+	"a=rtcp-fb:* nack\r\n" +
+	"m=application 9 UDP/DTLS/SCTP webrtc-datachannel\r\n" +
+	"c=IN IP4 0.0.0.0\r\n" +
+	"a=mid:2\r\n" +
+	"a=sctp-port:5000\r\n" +
+	"m=audio 0 UDP/TLS/RTP/SAVPF 0\r\n" +
+	"c=IN IP4 0.0.0.0\r\n"
+
+func TestValidationIssue_String(t *testing.T) {
+	assert.Equal(t, "error: m-section 1: a=rtcp-mux: sdp: missing rtcp-mux", ValidationIssue{
+		Severity:   ValidationError,
+		MediaIndex: 1,
+		Key:        AttrKeyRTCPMux,
+		Err:        errJSEPMissingRTCPMux,
+	}.String())
+	assert.Equal(t, "warning: session: sdp: unknown msid-semantic", ValidationIssue{
+		Severity:   ValidationWarning,
+		MediaIndex: -1,
+		Err:        errJSEPUnknownMsidSemantic,
+	}.String())
+	assert.Equal(t, "", ValidationSeverity(0).String())
+}
+
+func TestSessionDescription_ValidateJSEP_Valid(t *testing.T) {
+	var s SessionDescription
+	assert.NoError(t, s.UnmarshalString(jsepValidSDP))
+	assert.Empty(t, s.ValidateJSEP())
+}
+
+func TestSessionDescription_ValidateJSEP(t *testing.T) {
+	for _, test := range []struct {
+		Name     string
+		Replace  [2]string
+		Severity ValidationSeverity
+		Index    int
+		Key      string
+		Err      error
+	}{
+		{
+			Name:     "missing ice-pwd",
+			Replace:  [2]string{"a=ice-pwd:0123456789012345678901\r\n", ""},
+			Severity: ValidationError,
+			Key:      attrKeyICEUfrag,
+			Err:      errJSEPMissingICECredentials,
+		},
+		{
+			Name:     "missing fingerprint",
+			Replace:  [2]string{"a=fingerprint:sha-256 ", "a=x-fingerprint:sha-256 "},
+			Severity: ValidationError,
+			Key:      AttrKeyFingerprint,
+			Err:      errJSEPMissingFingerprint,
+		},
+		{
+			Name:     "invalid fingerprint",
+			Replace:  [2]string{"a=fingerprint:sha-256 6B", "a=fingerprint:sha-256 ZZ"},
+			Severity: ValidationError,
+			Key:      AttrKeyFingerprint,
+		},
+		{
+			Name:     "missing setup",
+			Replace:  [2]string{"a=setup:actpass\r\n", ""},
+			Severity: ValidationError,
+			Key:      AttrKeyConnectionSetup,
+			Err:      errJSEPMissingSetup,
+		},
+		{
+			Name:     "invalid setup",
+			Replace:  [2]string{"a=setup:actpass", "a=setup:both"},
+			Severity: ValidationError,
+			Key:      AttrKeyConnectionSetup,
+			Err:      errConnectionRole,
+		},
+		{
+			Name:     "missing mid",
+			Replace:  [2]string{"a=mid:2\r\n", ""},
+			Severity: ValidationError,
+			Index:    2,
+			Key:      AttrKeyMID,
+			Err:      errMissingMID,
+		},
+		{
+			Name:     "duplicate mid",
+			Replace:  [2]string{"a=mid:2\r\n", "a=mid:1\r\n"},
+			Severity: ValidationError,
+			Index:    2,
+			Key:      AttrKeyMID,
+			Err:      errJSEPDuplicateMID,
+		},
+		{
+			Name:     "BUNDLE with unknown mid",
+			Replace:  [2]string{"a=group:BUNDLE 0 1 2", "a=group:BUNDLE 0 1 2 3"},
+			Severity: ValidationError,
+			Index:    -1,
+			Key:      AttrKeyGroup,
+			Err:      errGroupUnknownMID,
+		},
+		{
+			Name:     "BUNDLE with bundle-only tag",
+			Replace:  [2]string{"a=group:BUNDLE 0 1 2", "a=group:BUNDLE 1 0 2"},
+			Severity: ValidationError,
+			Index:    -1,
+			Key:      AttrKeyGroup,
+			Err:      errBundleTagBundleOnly,
+		},
+		{
+			Name:     "bundle-only outside of BUNDLE",
+			Replace:  [2]string{"a=group:BUNDLE 0 1 2", "a=group:BUNDLE 0 2"},
+			Severity: ValidationError,
+			Index:    1,
+			Key:      AttrKeyBundleOnly,
+			Err:      errJSEPBundleOnlyNotBundled,
+		},
+		{
+			Name:     "extmap differs within BUNDLE",
+			Replace:  [2]string{"a=bundle-only\r\na=extmap:3 ", "a=bundle-only\r\na=extmap:4 "},
+			Severity: ValidationError,
+			Index:    -1,
+			Key:      AttrKeyGroup,
+			Err:      errExtMapBundleConflict,
+		},
+		{
+			Name:     "two-byte extmap ID",
+			Replace:  [2]string{"a=rtcp-mux\r\na=rtpmap:111", "a=extmap:15 urn:example:a\r\na=rtcp-mux\r\na=rtpmap:111"},
+			Severity: ValidationError,
+			Key:      AttrKeyExtMap,
+			Err:      errExtMapIDOutOfRange,
+		},
+		{
+			Name:     "missing rtcp-mux",
+			Replace:  [2]string{"a=rtcp-mux\r\na=rtpmap:96", "a=rtpmap:96"},
+			Severity: ValidationError,
+			Index:    1,
+			Key:      AttrKeyRTCPMux,
+			Err:      errJSEPMissingRTCPMux,
+		},
+		{
+			Name:     "invalid msid-semantic",
+			Replace:  [2]string{"a=msid-semantic: WMS *", "a=msid-semantic:"},
+			Severity: ValidationError,
+			Index:    -1,
+			Key:      AttrKeyMsidSemantic,
+			Err:      errJSEPInvalidMsidSemantic,
+		},
+		{
+			Name:     "unknown msid-semantic",
+			Replace:  [2]string{"a=msid-semantic: WMS *", "a=msid-semantic: XYZ"},
+			Severity: ValidationWarning,
+			Index:    -1,
+			Key:      AttrKeyMsidSemantic,
+			Err:      errJSEPUnknownMsidSemantic,
+		},
+		{
+			Name:     "unmapped dynamic payload type",
+			Replace:  [2]string{"a=rtpmap:96 VP8/90000\r\n", ""},
+			Severity: ValidationError,
+			Index:    1,
+			Key:      attrKeyRtpmap,
+			Err:      errJSEPUnmappedPayloadType,
+		},
+		{
+			Name:     "unmapped unassigned payload type",
+			Replace:  [2]string{"SAVPF 96\r\n", "SAVPF 35 96\r\n"},
+			Severity: ValidationError,
+			Index:    1,
+			Key:      attrKeyRtpmap,
+			Err:      errJSEPUnmappedPayloadType,
+		},
+		{
+			Name:     "static payload type of another media type",
+			Replace:  [2]string{"SAVPF 96\r\n", "SAVPF 96 0\r\n"},
+			Severity: ValidationError,
+			Index:    1,
+			Key:      attrKeyRtpmap,
+			Err:      errJSEPUnmappedPayloadType,
+		},
+		{
+			Name:     "fmtp for unlisted payload type",
+			Replace:  [2]string{"a=fmtp:111 ", "a=fmtp:112 "},
+			Severity: ValidationWarning,
+			Key:      attrKeyFmtp,
+			Err:      errJSEPUnlistedPayloadType,
+		},
+		{
+			Name:     "invalid payload type",
+			Replace:  [2]string{"SAVPF 111 0", "SAVPF 111 x"},
+			Severity: ValidationError,
+			Err:      errInvalidPayloadType,
+		},
+	} {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Contains(t, jsepValidSDP, test.Replace[0])
+
+			var s SessionDescription
+			assert.NoError(t, s.UnmarshalString(strings.Replace(jsepValidSDP, test.Replace[0], test.Replace[1], 1)))
+			// Follow-up issues, such as the transport of an m-section that lost
+			// its mid, may be reported after the first one.
+			issues := s.ValidateJSEP()
+			if assert.NotEmpty(t, issues) {
+				assert.Equal(t, test.Severity, issues[0].Severity)
+				assert.Equal(t, test.Index, issues[0].MediaIndex)
+				assert.Equal(t, test.Key, issues[0].Key)
+				assert.Error(t, issues[0].Err)
+				if test.Err != nil {
+					assert.ErrorIs(t, issues[0].Err, test.Err)
+				}
+			}
+		})
+	}
+}
+
+func TestSessionDescription_ValidateJSEP_Unbundled(t *testing.T) {
+	var s SessionDescription
+	assert.NoError(t, s.UnmarshalString(strings.Replace(jsepValidSDP, "a=group:BUNDLE 0 1 2\r\n", "", 1)))
+
+	// Without BUNDLE, every m-section carries its own transport.
+	var keys []string
+	for _, issue := range s.ValidateJSEP() {
+		keys = append(keys, issue.Key)
+	}
+	assert.Equal(t, []string{
+		AttrKeyBundleOnly,
+		attrKeyICEUfrag,
+		AttrKeyConnectionSetup,
+	}, keys)
+}